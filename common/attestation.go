@@ -0,0 +1,237 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// ImageVersionKey is the metadata key of the image version reported on the
+	// first line of an attestation record.
+	ImageVersionKey = "image_version"
+
+	// DigestSHA256 identifies SHA-256 checksums.
+	DigestSHA256 = "sha256"
+	// DigestSHA384 identifies SHA-384 checksums.
+	DigestSHA384 = "sha384"
+	// DigestSHA512 identifies SHA-512 checksums.
+	DigestSHA512 = "sha512"
+)
+
+// digestLengths maps the length of a hex encoded checksum to its digest algorithm.
+var digestLengths = map[int]string{
+	64:  DigestSHA256,
+	96:  DigestSHA384,
+	128: DigestSHA512,
+}
+
+// digestStrengths orders the digest algorithms by their output size in bits.
+var digestStrengths = map[string]int{
+	DigestSHA256: 256,
+	DigestSHA384: 384,
+	DigestSHA512: 512,
+}
+
+var (
+	// recordPattern matches a checksum line, consisting of a hex digest followed by the filename.
+	recordPattern = regexp.MustCompile(`^([a-fA-F0-9]{32,})\s+(\S.*)$`)
+	// metadataPattern matches a header line of the form "Key: value".
+	metadataPattern = regexp.MustCompile(`^([^:]+):\s*(.*)$`)
+	// nonAlphanumeric matches the characters replaced when normalizing metadata keys.
+	nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// AttestationRecord is a single checksum entry of an attestation record.
+type AttestationRecord struct {
+	Filename  string `json:"filename"`
+	Checksum  string `json:"checksum"`
+	Algorithm string `json:"algorithm"`
+}
+
+// Attestation is the parsed content of an attestation record (se-checksums.txt).
+type Attestation struct {
+	// Metadata maps normalized header names to their values.
+	Metadata map[string]string
+	// Records lists the checksum entries in the order of the attestation record.
+	Records []AttestationRecord
+}
+
+// Checksums returns the map from filename to checksum of the attestation records.
+func (a *Attestation) Checksums() map[string]string {
+	checksums := make(map[string]string, len(a.Records))
+	for _, record := range a.Records {
+		checksums[record.Filename] = record.Checksum
+	}
+	return checksums
+}
+
+// Algorithm returns the weakest digest algorithm used by the attestation records.
+func (a *Attestation) Algorithm() string {
+	weakest := ""
+	for _, record := range a.Records {
+		if weakest == "" || digestStrengths[record.Algorithm] < digestStrengths[weakest] {
+			weakest = record.Algorithm
+		}
+	}
+	return weakest
+}
+
+// RequireAlgorithm returns an error if any attestation record uses a digest
+// algorithm weaker than minimum.
+func (a *Attestation) RequireAlgorithm(minimum string) error {
+	minimumStrength, ok := digestStrengths[minimum]
+	if !ok {
+		return fmt.Errorf("unsupported digest algorithm %q, expected one of %q, %q, %q", minimum, DigestSHA256, DigestSHA384, DigestSHA512)
+	}
+
+	var weak []string
+	for _, record := range a.Records {
+		if digestStrengths[record.Algorithm] < minimumStrength {
+			weak = append(weak, fmt.Sprintf("%s (%s)", record.Filename, record.Algorithm))
+		}
+	}
+	if len(weak) > 0 {
+		return fmt.Errorf("checksums weaker than %s: %s", minimum, strings.Join(weak, ", "))
+	}
+
+	return nil
+}
+
+// DigestAlgorithm detects the digest algorithm of a hex encoded checksum from its length.
+func DigestAlgorithm(checksum string) (string, error) {
+	algorithm, ok := digestLengths[len(checksum)]
+	if !ok {
+		return "", fmt.Errorf("unsupported checksum length %d in %s", len(checksum), checksum)
+	}
+	return algorithm, nil
+}
+
+// ParseAttestation parses a decrypted attestation record. The first line may
+// hold the image version, header lines of the form "Key: value" are returned
+// as metadata with snake_case keys, and checksum lines are returned as records.
+// Lines that match none of these forms and duplicate entries are rejected.
+func ParseAttestation(input string) (*Attestation, error) {
+	result := &Attestation{
+		Metadata: make(map[string]string),
+		Records:  []AttestationRecord{},
+	}
+	filenames := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	lineNumber := 0
+	firstLine := true
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if matches := recordPattern.FindStringSubmatch(line); matches != nil {
+			algorithm, err := DigestAlgorithm(matches[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			filename := strings.TrimSpace(matches[2])
+			if filenames[filename] {
+				return nil, fmt.Errorf("line %d: duplicate checksum for %s", lineNumber, filename)
+			}
+			filenames[filename] = true
+			result.Records = append(result.Records, AttestationRecord{
+				Filename:  filename,
+				Checksum:  matches[1],
+				Algorithm: algorithm,
+			})
+		} else if matches := metadataPattern.FindStringSubmatch(line); matches != nil {
+			key := normalizeMetadataKey(matches[1])
+			if key == "" {
+				return nil, fmt.Errorf("line %d: invalid header %q", lineNumber, line)
+			}
+			if _, ok := result.Metadata[key]; ok {
+				return nil, fmt.Errorf("line %d: duplicate header %s", lineNumber, key)
+			}
+			result.Metadata[key] = strings.TrimSpace(matches[2])
+		} else if firstLine && !strings.ContainsAny(line, " \t") {
+			result.Metadata[ImageVersionKey] = line
+		} else {
+			return nil, fmt.Errorf("line %d: malformed attestation line %q", lineNumber, line)
+		}
+		firstLine = false
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read attestation record: %v", err)
+	}
+
+	if len(result.Records) == 0 {
+		return nil, fmt.Errorf("attestation record does not contain any checksum")
+	}
+
+	return result, nil
+}
+
+// normalizeMetadataKey converts an attestation header name to snake_case,
+// e.g. "Machine Type/Plant/Serial" becomes "machine_type_plant_serial".
+func normalizeMetadataKey(key string) string {
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(key), "_"), "_")
+}
+
+// VerifyChecksums compares the checksums of an attestation record with the
+// expected checksums and returns the sorted names of the files whose checksum
+// differs or that are missing from the attestation record. Checksums are
+// compared case-insensitively.
+func VerifyChecksums(actual, expected map[string]string) []string {
+	mismatches := []string{}
+	for filename, expectedChecksum := range expected {
+		actualChecksum, ok := actual[filename]
+		if !ok || !strings.EqualFold(strings.TrimSpace(actualChecksum), strings.TrimSpace(expectedChecksum)) {
+			mismatches = append(mismatches, filename)
+		}
+	}
+	sort.Strings(mismatches)
+
+	return mismatches
+}
+
+// DiffChecksums compares the checksums of two attestation records and returns
+// the sorted names of the files that were added, removed or whose checksum
+// changed between the old and the new record.
+func DiffChecksums(oldChecksums, newChecksums map[string]string) (added, removed, changed []string) {
+	added, removed, changed = []string{}, []string{}, []string{}
+
+	for filename, newChecksum := range newChecksums {
+		oldChecksum, ok := oldChecksums[filename]
+		if !ok {
+			added = append(added, filename)
+		} else if !strings.EqualFold(oldChecksum, newChecksum) {
+			changed = append(changed, filename)
+		}
+	}
+	for filename := range oldChecksums {
+		if _, ok := newChecksums[filename]; !ok {
+			removed = append(removed, filename)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+
+	return added, removed, changed
+}
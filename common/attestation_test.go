@@ -0,0 +1,194 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyChecksums(t *testing.T) {
+	actual := map[string]string{
+		"cidata/user-data":  "AAAA",
+		"contract:workload": "bbbb",
+		"contract:env":      "cccc",
+	}
+	expected := map[string]string{
+		"cidata/user-data":  "aaaa",
+		"contract:workload": "ffff",
+		"contract:missing":  "dddd",
+	}
+
+	mismatches := VerifyChecksums(actual, expected)
+
+	want := []string{"contract:missing", "contract:workload"}
+	if strings.Join(mismatches, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected mismatches %v, got %v", want, mismatches)
+	}
+}
+
+func TestVerifyChecksums_AllMatch(t *testing.T) {
+	actual := map[string]string{"contract:env": "cccc"}
+
+	if mismatches := VerifyChecksums(actual, map[string]string{"contract:env": "cccc"}); len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches, got %v", mismatches)
+	}
+}
+
+const testAttestation = `25.11.0
+Machine Type/Plant/Serial: 9175/02/C25B8
+Image age: 3 days since creation.
+HKD is valid until: Apr 1 15:03:09 2027 GMT
+Number of configured APs: 1
+42766688f5ff825316e1c21f7f181017feac63f0e418038f9b4739b62b7f95e4 AP(1):secret
+c5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4 cidata/user-data
+97b38579c16f0f3c0e25d5cc6582f958d867eab77d082a91576d1beacbda11e4 contract:workload
+`
+
+func TestParseAttestation(t *testing.T) {
+	result, err := ParseAttestation(testAttestation)
+	if err != nil {
+		t.Fatalf("ParseAttestation() failed: %v", err)
+	}
+
+	expectedMetadata := map[string]string{
+		"image_version":             "25.11.0",
+		"machine_type_plant_serial": "9175/02/C25B8",
+		"image_age":                 "3 days since creation.",
+		"hkd_is_valid_until":        "Apr 1 15:03:09 2027 GMT",
+		"number_of_configured_aps":  "1",
+	}
+	if len(result.Metadata) != len(expectedMetadata) {
+		t.Errorf("Expected %d metadata entries, got %v", len(expectedMetadata), result.Metadata)
+	}
+	for key, value := range expectedMetadata {
+		if result.Metadata[key] != value {
+			t.Errorf("Expected metadata '%s' to be '%s', got '%s'", key, value, result.Metadata[key])
+		}
+	}
+
+	expectedFilenames := []string{"AP(1):secret", "cidata/user-data", "contract:workload"}
+	if len(result.Records) != len(expectedFilenames) {
+		t.Fatalf("Expected %d records, got %d", len(expectedFilenames), len(result.Records))
+	}
+	for i, filename := range expectedFilenames {
+		if result.Records[i].Filename != filename {
+			t.Errorf("Expected record %d to be '%s', got '%s'", i, filename, result.Records[i].Filename)
+		}
+	}
+
+	checksums := result.Checksums()
+	if checksums["cidata/user-data"] != "c5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4" {
+		t.Errorf("Unexpected checksum for cidata/user-data: %s", checksums["cidata/user-data"])
+	}
+}
+
+func TestParseAttestation_DuplicateChecksums(t *testing.T) {
+	// Identical checksums for different files must not collide
+	input := "c5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4 a\nc5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4 b\n"
+
+	result, err := ParseAttestation(input)
+	if err != nil {
+		t.Fatalf("ParseAttestation() failed: %v", err)
+	}
+	if len(result.Checksums()) != 2 {
+		t.Errorf("Expected 2 checksums, got %v", result.Checksums())
+	}
+}
+
+func TestParseAttestation_Invalid(t *testing.T) {
+	invalid := map[string]string{
+		"empty":                "",
+		"no records":           "25.11.0\nImage age: 3 days\n",
+		"malformed line":       "25.11.0\nthis is not valid\nc5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4 a\n",
+		"truncated checksum":   "c5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033acc a\n",
+		"truncated with colon": "c5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033acc contract:env\n",
+		"duplicate filename":   "c5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4 a\n97b38579c16f0f3c0e25d5cc6582f958d867eab77d082a91576d1beacbda11e4 a\n",
+	}
+
+	for name, input := range invalid {
+		if _, err := ParseAttestation(input); err == nil {
+			t.Errorf("ParseAttestation() should fail for %s", name)
+		}
+	}
+}
+
+func TestDigestAlgorithm(t *testing.T) {
+	tests := map[int]string{64: DigestSHA256, 96: DigestSHA384, 128: DigestSHA512}
+
+	for length, expected := range tests {
+		algorithm, err := DigestAlgorithm(strings.Repeat("a", length))
+		if err != nil {
+			t.Fatalf("DigestAlgorithm() failed for length %d: %v", length, err)
+		}
+		if algorithm != expected {
+			t.Errorf("Expected %s for length %d, got %s", expected, length, algorithm)
+		}
+	}
+
+	if _, err := DigestAlgorithm(strings.Repeat("a", 40)); err == nil {
+		t.Error("DigestAlgorithm() should fail for an unsupported length")
+	}
+}
+
+func TestParseAttestation_MixedAlgorithms(t *testing.T) {
+	input := strings.Repeat("a", 128) + " contract:workload\n" + strings.Repeat("b", 96) + " contract:env\n"
+
+	result, err := ParseAttestation(input)
+	if err != nil {
+		t.Fatalf("ParseAttestation() failed: %v", err)
+	}
+	if result.Records[0].Algorithm != DigestSHA512 || result.Records[1].Algorithm != DigestSHA384 {
+		t.Errorf("Unexpected record algorithms: %v", result.Records)
+	}
+	if result.Algorithm() != DigestSHA384 {
+		t.Errorf("Expected weakest algorithm %s, got %s", DigestSHA384, result.Algorithm())
+	}
+
+	if err := result.RequireAlgorithm(DigestSHA384); err != nil {
+		t.Errorf("RequireAlgorithm(%s) failed: %v", DigestSHA384, err)
+	}
+	if err := result.RequireAlgorithm(DigestSHA512); err == nil {
+		t.Errorf("RequireAlgorithm(%s) should fail for SHA-384 records", DigestSHA512)
+	}
+	if err := result.RequireAlgorithm("md5"); err == nil {
+		t.Error("RequireAlgorithm() should fail for an unsupported algorithm")
+	}
+}
+
+func TestDiffChecksums(t *testing.T) {
+	old := map[string]string{
+		"cidata/user-data":  "aaaa",
+		"contract:workload": "bbbb",
+		"contract:env":      "cccc",
+	}
+	updated := map[string]string{
+		"cidata/user-data":              "AAAA",
+		"contract:workload":             "ffff",
+		"contract:attestationPublicKey": "dddd",
+	}
+
+	added, removed, changed := DiffChecksums(old, updated)
+
+	if strings.Join(added, ",") != "contract:attestationPublicKey" {
+		t.Errorf("Unexpected added files: %v", added)
+	}
+	if strings.Join(removed, ",") != "contract:env" {
+		t.Errorf("Unexpected removed files: %v", removed)
+	}
+	if strings.Join(changed, ",") != "contract:workload" {
+		t.Errorf("Unexpected changed files: %v", changed)
+	}
+}
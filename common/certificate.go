@@ -0,0 +1,146 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// ParseCertificates parses all PEM encoded certificates of the input.
+func ParseCertificates(input string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(input)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+
+	return certs, nil
+}
+
+// IsSelfSigned reports whether a certificate is signed by its own key.
+func IsSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// VerifyCertificateChain validates a certificate against a trust bundle at the
+// given time. The first certificate of certPEM is the leaf, any further
+// certificates are used as intermediates. Every certificate of the chain is
+// checked against the CRLs of its issuer, if any are provided. It returns the
+// leaf certificate.
+func VerifyCertificateChain(certPEM, trustBundlePEM string, crlPEMs []string, now time.Time) (*x509.Certificate, error) {
+	certs, err := ParseCertificates(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %v", err)
+	}
+	roots, err := ParseCertificates(trustBundlePEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust bundle: %v", err)
+	}
+
+	options := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, root := range roots {
+		options.Roots.AddCert(root)
+	}
+	for _, intermediate := range certs[1:] {
+		options.Intermediates.AddCert(intermediate)
+	}
+
+	leaf := certs[0]
+	chains, err := leaf.Verify(options)
+	if err != nil {
+		return nil, fmt.Errorf("certificate chain validation failed: %v", err)
+	}
+
+	crls, err := parseRevocationLists(crlPEMs)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevocation(chains[0], crls, now); err != nil {
+		return nil, err
+	}
+
+	return leaf, nil
+}
+
+// parseRevocationLists parses PEM encoded certificate revocation lists.
+func parseRevocationLists(crlPEMs []string) ([]*x509.RevocationList, error) {
+	var crls []*x509.RevocationList
+	for index, crlPEM := range crlPEMs {
+		block, _ := pem.Decode([]byte(crlPEM))
+		if block == nil || block.Type != "X509 CRL" {
+			return nil, fmt.Errorf("CRL %d is not a PEM encoded X509 CRL", index)
+		}
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CRL %d: %v", index, err)
+		}
+		crls = append(crls, crl)
+	}
+	return crls, nil
+}
+
+// checkRevocation checks every certificate of a verified chain against the
+// CRLs issued by its parent.
+func checkRevocation(chain []*x509.Certificate, crls []*x509.RevocationList, now time.Time) error {
+	for i := 0; i+1 < len(chain); i++ {
+		cert, issuer := chain[i], chain[i+1]
+
+		for _, crl := range crls {
+			if !bytes.Equal(crl.RawIssuer, cert.RawIssuer) {
+				continue
+			}
+			if err := crl.CheckSignatureFrom(issuer); err != nil {
+				return fmt.Errorf("CRL of %s has an invalid signature: %v", issuer.Subject, err)
+			}
+			if !crl.NextUpdate.IsZero() && now.After(crl.NextUpdate) {
+				return fmt.Errorf("CRL of %s expired on %s", issuer.Subject, crl.NextUpdate.Format(time.RFC3339))
+			}
+			for _, revoked := range crl.RevokedCertificateEntries {
+				if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+					return fmt.Errorf("certificate %s has been revoked on %s", cert.Subject, revoked.RevocationTime.Format(time.RFC3339))
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  string
+}
+
+func newTestCert(t *testing.T, subject string, serial int64, notAfter time.Time, parent *testCA) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: subject},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
+
+func newTestCRL(t *testing.T, issuer *testCA, revoked ...*big.Int) string {
+	t.Helper()
+
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: time.Now().Add(24 * time.Hour),
+	}
+	for _, serial := range revoked {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, template, issuer.cert, issuer.key)
+	if err != nil {
+		t.Fatalf("Failed to create CRL: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}))
+}
+
+func TestVerifyCertificateChain(t *testing.T) {
+	root := newTestCert(t, "root", 1, time.Now().Add(24*time.Hour), nil)
+	leaf := newTestCert(t, "attestation", 2, time.Now().Add(time.Hour), root)
+
+	verified, err := VerifyCertificateChain(leaf.pem, root.pem, []string{newTestCRL(t, root)}, time.Now())
+	if err != nil {
+		t.Fatalf("VerifyCertificateChain() failed: %v", err)
+	}
+	if verified.Subject.CommonName != "attestation" {
+		t.Errorf("Expected leaf certificate, got %s", verified.Subject)
+	}
+}
+
+func TestVerifyCertificateChain_SelfSigned(t *testing.T) {
+	root := newTestCert(t, "root", 1, time.Now().Add(24*time.Hour), nil)
+	selfSigned := newTestCert(t, "attestation", 2, time.Now().Add(time.Hour), nil)
+
+	if _, err := VerifyCertificateChain(selfSigned.pem, root.pem, nil, time.Now()); err == nil {
+		t.Error("VerifyCertificateChain() should reject a certificate that is not issued by the trust bundle")
+	}
+}
+
+func TestIsSelfSigned(t *testing.T) {
+	root := newTestCert(t, "root", 1, time.Now().Add(24*time.Hour), nil)
+	leaf := newTestCert(t, "attestation", 2, time.Now().Add(time.Hour), root)
+
+	if !IsSelfSigned(root.cert) {
+		t.Error("Expected the root certificate to be self-signed")
+	}
+	if IsSelfSigned(leaf.cert) {
+		t.Error("Expected a certificate issued by the root not to be self-signed")
+	}
+}
+
+func TestVerifyCertificateChain_Expired(t *testing.T) {
+	root := newTestCert(t, "root", 1, time.Now().Add(24*time.Hour), nil)
+	leaf := newTestCert(t, "attestation", 2, time.Now().Add(time.Hour), root)
+
+	if _, err := VerifyCertificateChain(leaf.pem, root.pem, nil, time.Now().Add(2*time.Hour)); err == nil {
+		t.Error("VerifyCertificateChain() should reject an expired certificate")
+	}
+}
+
+func TestVerifyCertificateChain_Revoked(t *testing.T) {
+	root := newTestCert(t, "root", 1, time.Now().Add(24*time.Hour), nil)
+	leaf := newTestCert(t, "attestation", 2, time.Now().Add(time.Hour), root)
+
+	crl := newTestCRL(t, root, leaf.cert.SerialNumber)
+	if _, err := VerifyCertificateChain(leaf.pem, root.pem, []string{crl}, time.Now()); err == nil {
+		t.Error("VerifyCertificateChain() should reject a revoked certificate")
+	}
+}
+
+func TestVerifyCertificateChain_InvalidInput(t *testing.T) {
+	root := newTestCert(t, "root", 1, time.Now().Add(24*time.Hour), nil)
+
+	if _, err := VerifyCertificateChain("not a certificate", root.pem, nil, time.Now()); err == nil {
+		t.Error("VerifyCertificateChain() should fail for an invalid certificate")
+	}
+	if _, err := VerifyCertificateChain(root.pem, root.pem, []string{"not a CRL"}, time.Now()); err == nil {
+		t.Error("VerifyCertificateChain() should fail for an invalid CRL")
+	}
+}
@@ -15,23 +15,67 @@
 package common
 
 import (
-	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math/big"
 	"os"
 	"os/exec"
-	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-uuid"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// IDModeRandom assigns a random UUID to new resources.
+	IDModeRandom = "random"
+	// IDModeStable derives the ID of new resources from their inputs.
+	IDModeStable = "stable"
+)
+
+// ProviderData is the provider configuration passed to resources and data sources.
+type ProviderData struct {
+	// IDMode is the scheme used for resource IDs, IDModeRandom or IDModeStable.
+	IDMode string
+}
+
 // GenerateID generates a random UUID to be used as a Terraform resource or data source ID.
 func GenerateID() (string, error) {
 	return uuid.GenerateUUID()
 }
 
+// StableID derives a deterministic UUID formatted ID from the given parts, so
+// that the same inputs always yield the same Terraform ID.
+func StableID(parts ...string) string {
+	digest := sha256.New()
+	for _, part := range parts {
+		// Prefix each part with its length so that different splits of the same text differ
+		fmt.Fprintf(digest, "%d:%s", len(part), part)
+	}
+	id, _ := uuid.FormatUUID(digest.Sum(nil)[:16])
+	return id
+}
+
+// ResourceID returns the ID of a new resource for the given ID mode, a random
+// UUID in IDModeRandom or the StableID of the parts in IDModeStable.
+func ResourceID(idMode string, parts ...string) (string, error) {
+	if idMode == IDModeStable {
+		return StableID(parts...), nil
+	}
+	return GenerateID()
+}
+
+// GenerateSha256 returns the hex encoded SHA256 checksum of the input.
+func GenerateSha256(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])
+}
+
 // GeneratePrivateKey generates a 4096-bit RSA private key using OpenSSL.
 // It respects the OPENSSL_BIN environment variable for the OpenSSL binary path.
 func GeneratePrivateKey() (string, error) {
@@ -73,82 +117,293 @@ func ReadFileData(filePath string) (string, error) {
 	return string(content), nil
 }
 
-func FilterChecksum(input string) map[string]string {
-	// SHA-256: 64 hex chars
-	re := regexp.MustCompile(`^([a-fA-F0-9]{64})\s+(.+)$`)
+// RefineContract rewrites the env and workload sections of a contract as
+// literal block strings, which is the form expected by the contract encryption.
+// The contract is processed as a yaml.v3 node tree so that key order, comments
+// and anchors are preserved and the output is identical across runs. Aliases
+// and merge keys are expanded inside the sections, and aliases elsewhere that
+// refer into a section are expanded too, so that no alias is left dangling.
+// Sections that are already strings, including hyper-protect-basic encrypted
+// sections, are left untouched.
+func RefineContract(yamlStr string) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlStr), &document); err != nil {
+		return "", fmt.Errorf("failed to unmarshal input YAML: %v", err)
+	}
+
+	// An empty contract has no document content
+	if len(document.Content) == 0 {
+		return "{}\n", nil
+	}
 
-	scanner := bufio.NewScanner(strings.NewReader(input))
-	checksumMap := make(map[string]string)
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("contract must be a YAML mapping")
+	}
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
+	// Keys that should be serialized as YAML strings
+	serializeKeys := map[string]bool{
+		"env":      true,
+		"workload": true,
+	}
+
+	// Nodes of the refined sections, which are no longer available as anchors
+	refined := map[*yaml.Node]bool{}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode := root.Content[i]
+		if !serializeKeys[keyNode.Value] {
 			continue
 		}
 
-		matches := re.FindStringSubmatch(line)
-		if len(matches) != 3 {
-			continue
+		valueNode, err := refineSection(keyNode.Value, root.Content[i+1])
+		if err != nil {
+			return "", err
+		}
+		if valueNode != root.Content[i+1] {
+			collectNodes(root.Content[i+1], refined)
 		}
+		root.Content[i+1] = valueNode
+	}
+	expandAliasesInto(root, refined)
 
-		checksumMap[matches[1]] = matches[2]
+	resultBytes, err := yaml.Marshal(&document)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal final YAML: %v", err)
 	}
 
-	return checksumMap
+	return string(resultBytes), nil
 }
 
-func RefineContract(yamlStr string) (string, error) {
-	var data map[string]interface{}
-	if err := yaml.Unmarshal([]byte(yamlStr), &data); err != nil {
-		return "", fmt.Errorf("failed to unmarshal input YAML: %v", err)
+// refineSection returns a literal block scalar holding the YAML serialization
+// of a contract section. String sections are returned as they are.
+func refineSection(key string, valueNode *yaml.Node) (*yaml.Node, error) {
+	// Serialize the expanded content, the anchors are not available in the string
+	section := expandNode(valueNode)
+	if section.Kind == yaml.ScalarNode && section.ShortTag() == "!!str" {
+		return valueNode, nil
 	}
 
-	// Keys that should be serialized as YAML strings
-	serializeKeys := map[string]bool{
-		"env":      true,
-		"workload": true,
+	contentBytes, err := yaml.Marshal(section)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key %s: %v", key, err)
 	}
 
-	finalNode := &yaml.Node{
-		Kind:    yaml.MappingNode,
-		Content: []*yaml.Node{},
+	return &yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Style:       yaml.LiteralStyle,
+		Tag:         "!!str",
+		Value:       string(contentBytes),
+		LineComment: valueNode.LineComment,
+	}, nil
+}
+
+// expandNode returns a deep copy of node with all aliases resolved, merge keys
+// applied and anchors removed. Keys of a mapping take precedence over merged
+// keys, and earlier merged mappings over later ones, as defined for YAML merge
+// keys. Merged keys are inserted at the position of the merge key.
+func expandNode(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		expanded := expandNode(node.Alias)
+		expanded.HeadComment, expanded.LineComment, expanded.FootComment = node.HeadComment, node.LineComment, node.FootComment
+		return expanded
 	}
 
-	for key, val := range data {
-		keyNode := &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Value: key,
+	expanded := *node
+	expanded.Anchor = ""
+	expanded.Content = nil
+	if node.Kind != yaml.MappingNode {
+		for _, child := range node.Content {
+			expanded.Content = append(expanded.Content, expandNode(child))
 		}
+		return &expanded
+	}
 
-		var valueNode *yaml.Node
+	explicit := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isMergeKey(node.Content[i]) {
+			explicit[node.Content[i].Value] = true
+		}
+	}
 
-		if serializeKeys[key] {
-			// Marshal value back to YAML and store as block literal
-			contentBytes, err := yaml.Marshal(val)
-			if err != nil {
-				return "", fmt.Errorf("failed to marshal key %s: %v", key, err)
-			}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !isMergeKey(key) {
+			expanded.Content = append(expanded.Content, expandNode(key), expandNode(value))
+			continue
+		}
 
-			valueNode = &yaml.Node{
-				Kind:  yaml.ScalarNode,
-				Style: yaml.LiteralStyle,
-				Value: string(contentBytes),
+		// A merge key refers to a mapping or to a sequence of mappings
+		merged := expandNode(value)
+		sources := []*yaml.Node{merged}
+		if merged.Kind == yaml.SequenceNode {
+			sources = merged.Content
+		}
+		for _, source := range sources {
+			if source.Kind != yaml.MappingNode {
+				continue
 			}
-		} else {
-			// Normal YAML encoding
-			valueNode = &yaml.Node{}
-			if err := valueNode.Encode(val); err != nil {
-				return "", fmt.Errorf("failed to encode key %s: %v", key, err)
+			for j := 0; j+1 < len(source.Content); j += 2 {
+				if explicit[source.Content[j].Value] {
+					continue
+				}
+				explicit[source.Content[j].Value] = true
+				expanded.Content = append(expanded.Content, source.Content[j], source.Content[j+1])
 			}
 		}
+	}
+
+	return &expanded
+}
+
+// isMergeKey reports whether node is the YAML merge key <<.
+func isMergeKey(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Value == "<<" && (node.Tag == "" || node.Tag == "!!merge" || node.Tag == "tag:yaml.org,2002:merge")
+}
+
+// collectNodes adds node and all nodes below it to nodes.
+func collectNodes(node *yaml.Node, nodes map[*yaml.Node]bool) {
+	nodes[node] = true
+	for _, child := range node.Content {
+		collectNodes(child, nodes)
+	}
+}
+
+// expandAliasesInto replaces the aliases below node that refer to one of the
+// given nodes by an expanded copy of their content.
+func expandAliasesInto(node *yaml.Node, targets map[*yaml.Node]bool) {
+	for i, child := range node.Content {
+		if child.Kind == yaml.AliasNode && targets[child.Alias] {
+			node.Content[i] = expandNode(child)
+			continue
+		}
+		expandAliasesInto(child, targets)
+	}
+}
+
+// CanonicalJSON parses a JSON document of any type, including top-level arrays
+// and scalars, and re-serializes it with sorted object keys. Numbers are
+// normalized with CanonicalNumber.
+func CanonicalJSON(input string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
 
-		finalNode.Content = append(finalNode.Content, keyNode, valueNode)
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return "", fmt.Errorf("failed to decode JSON: %v", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", fmt.Errorf("failed to decode JSON: unexpected data after the JSON document")
 	}
 
-	resultBytes, err := yaml.Marshal(finalNode)
+	data, err := canonicalNumbers(data)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal final YAML: %v", err)
+		return "", err
 	}
 
-	return string(resultBytes), nil
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// canonicalNumbers replaces the numbers of a decoded JSON document with their
+// canonical form.
+func canonicalNumbers(data interface{}) (interface{}, error) {
+	switch value := data.(type) {
+	case json.Number:
+		return CanonicalNumber(value.String())
+	case []interface{}:
+		for i, element := range value {
+			converted, err := canonicalNumbers(element)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = converted
+		}
+	case map[string]interface{}:
+		for key, element := range value {
+			converted, err := canonicalNumbers(element)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = converted
+		}
+	}
+	return data, nil
+}
+
+// CanonicalNumber formats a decimal number the way encoding/json formats a
+// float64, so that 1.0 and 1e3 become 1 and 1000 as in earlier releases.
+// Numbers that a float64 cannot hold exactly keep all their digits instead.
+func CanonicalNumber(number string) (json.Number, error) {
+	exact, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return "", fmt.Errorf("invalid number %q", number)
+	}
+
+	if float, err := strconv.ParseFloat(number, 64); err == nil {
+		shortest, _ := new(big.Rat).SetString(strconv.FormatFloat(float, 'g', -1, 64))
+		if shortest.Cmp(exact) == 0 {
+			encoded, err := json.Marshal(float)
+			if err != nil {
+				return "", fmt.Errorf("failed to marshal number %q: %v", number, err)
+			}
+			return json.Number(encoded), nil
+		}
+	}
+
+	if exact.IsInt() {
+		return json.Number(exact.Num().String()), nil
+	}
+
+	// A decimal number has a denominator that divides a power of ten
+	digits := 1
+	for power := big.NewInt(10); new(big.Int).Mod(power, exact.Denom()).Sign() != 0; digits++ {
+		power.Mul(power, big.NewInt(10))
+	}
+	return json.Number(exact.FloatString(digits)), nil
+}
+
+// NormalizeYAML validates a YAML document and re-serializes it through the
+// yaml.v3 node representation, which yields a stable formatting while keeping
+// key order and comments. Input with more than one document is rejected
+// unless multiDocument is set.
+func NormalizeYAML(input string, multiDocument bool) (string, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(input))
+
+	var documents []*yaml.Node
+	for {
+		document := &yaml.Node{}
+		err := decoder.Decode(document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal input YAML: %v", err)
+		}
+		documents = append(documents, document)
+	}
+
+	if len(documents) == 0 {
+		return "", fmt.Errorf("input YAML does not contain a document")
+	}
+	if len(documents) > 1 && !multiDocument {
+		return "", fmt.Errorf("input YAML contains %d documents, multiple documents are not enabled", len(documents))
+	}
+
+	var result strings.Builder
+	for index, document := range documents {
+		if index > 0 {
+			result.WriteString("---\n")
+		}
+		documentBytes, err := yaml.Marshal(document)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal YAML document: %v", err)
+		}
+		result.Write(documentBytes)
+	}
+
+	return result.String(), nil
 }
@@ -18,6 +18,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestGenerateID(t *testing.T) {
@@ -72,6 +74,45 @@ func TestGenerateID_Uniqueness(t *testing.T) {
 	}
 }
 
+func TestStableID(t *testing.T) {
+	id := StableID("image", "r006-1234", "1.0.23")
+
+	if id != StableID("image", "r006-1234", "1.0.23") {
+		t.Error("StableID() returned different IDs for the same parts")
+	}
+	if len(id) != 36 || strings.Count(id, "-") != 4 {
+		t.Errorf("StableID() did not return a UUID formatted ID, got %q", id)
+	}
+	if id == StableID("image", "r006-1234", "1.0.24") {
+		t.Error("StableID() returned the same ID for different parts")
+	}
+	if StableID("ab", "c") == StableID("a", "bc") {
+		t.Error("StableID() returned the same ID for differently split parts")
+	}
+}
+
+func TestResourceID(t *testing.T) {
+	stable, err := ResourceID(IDModeStable, "text", "hello")
+	if err != nil {
+		t.Fatalf("ResourceID() failed: %v", err)
+	}
+	if stable != StableID("text", "hello") {
+		t.Errorf("ResourceID() in stable mode = %q, want %q", stable, StableID("text", "hello"))
+	}
+
+	first, err := ResourceID(IDModeRandom, "text", "hello")
+	if err != nil {
+		t.Fatalf("ResourceID() failed: %v", err)
+	}
+	second, err := ResourceID("", "text", "hello")
+	if err != nil {
+		t.Fatalf("ResourceID() failed: %v", err)
+	}
+	if first == second || first == stable {
+		t.Error("ResourceID() in random mode returned a deterministic ID")
+	}
+}
+
 func TestReadFileData(t *testing.T) {
 	// Create a temporary file with test content
 	tmpFile := t.TempDir() + "/test.txt"
@@ -103,3 +144,274 @@ func TestReadFileData_NonExistentFile(t *testing.T) {
 		t.Errorf("Expected error message to contain 'does not exist', got: %s", err.Error())
 	}
 }
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"object with sorted keys", `{"b": 1, "a": {"d": true, "c": null}}`, `{"a":{"c":null,"d":true},"b":1}`},
+		{"top-level array", `[3, "two", {"z": 1, "y": 2}]`, `[3,"two",{"y":2,"z":1}]`},
+		{"top-level string", `"hello"`, `"hello"`},
+		{"top-level number", `12345678901234567890`, `12345678901234567890`},
+		{"decimal number", `{"pi": 3.14159}`, `{"pi":3.14159}`},
+		{"trailing zero", `{"a": 1.0}`, `{"a":1}`},
+		{"exponent", `[1e3, 1E21, 2.5e-7]`, `[1000,1e+21,2.5e-7]`},
+		{"negative zero", `-0.0`, `-0`},
+		{"exact decimal", `0.12345678901234567890123`, `0.12345678901234567890123`},
+		{"exact decimal exponent", `1.5000000000000000000001e2`, `150.00000000000000000001`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := CanonicalJSON(tt.input)
+			if err != nil {
+				t.Fatalf("CanonicalJSON() failed: %v", err)
+			}
+			if output != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestCanonicalNumber(t *testing.T) {
+	tests := map[string]string{
+		"1":                     "1",
+		"1.0":                   "1",
+		"0.1":                   "0.1",
+		"-12.50":                "-12.5",
+		"1e21":                  "1e+21",
+		"123456789012345678901": "123456789012345678901",
+	}
+	for input, expected := range tests {
+		number, err := CanonicalNumber(input)
+		if err != nil {
+			t.Fatalf("CanonicalNumber(%q) failed: %v", input, err)
+		}
+		if number.String() != expected {
+			t.Errorf("CanonicalNumber(%q): expected %s, got %s", input, expected, number)
+		}
+	}
+
+	if _, err := CanonicalNumber("one"); err == nil {
+		t.Error("CanonicalNumber() should fail for an invalid number")
+	}
+}
+
+func TestCanonicalJSON_Invalid(t *testing.T) {
+	invalid := []string{"", "{", `{"a": 1} {"b": 2}`, "not json"}
+
+	for _, input := range invalid {
+		if _, err := CanonicalJSON(input); err == nil {
+			t.Errorf("CanonicalJSON(%q) should return an error", input)
+		}
+	}
+}
+
+func TestNormalizeYAML(t *testing.T) {
+	input := "# compose file\nservices:\n  app:\n      image: busybox  # pinned\n  db:   {image: postgres}\n"
+
+	output, err := NormalizeYAML(input, false)
+	if err != nil {
+		t.Fatalf("NormalizeYAML() failed: %v", err)
+	}
+
+	// Key order and comments are preserved
+	if strings.Index(output, "app:") > strings.Index(output, "db:") {
+		t.Errorf("Expected key order to be preserved, got:\n%s", output)
+	}
+	if !strings.Contains(output, "# compose file") || !strings.Contains(output, "# pinned") {
+		t.Errorf("Expected comments to be preserved, got:\n%s", output)
+	}
+
+	// Normalizing again yields the same output
+	again, err := NormalizeYAML(output, false)
+	if err != nil {
+		t.Fatalf("NormalizeYAML() failed: %v", err)
+	}
+	if again != output {
+		t.Errorf("Expected normalization to be stable, got:\n%s\nand:\n%s", output, again)
+	}
+}
+
+func TestNormalizeYAML_MultiDocument(t *testing.T) {
+	input := "kind: Pod\n---\nkind: ConfigMap\n"
+
+	if _, err := NormalizeYAML(input, false); err == nil {
+		t.Error("NormalizeYAML() should reject multiple documents unless enabled")
+	}
+
+	output, err := NormalizeYAML(input, true)
+	if err != nil {
+		t.Fatalf("NormalizeYAML() failed: %v", err)
+	}
+	if output != "kind: Pod\n---\nkind: ConfigMap\n" {
+		t.Errorf("Unexpected multi document output:\n%s", output)
+	}
+}
+
+func TestNormalizeYAML_Invalid(t *testing.T) {
+	invalid := []string{"", "key: [unclosed", "a: b: c"}
+
+	for _, input := range invalid {
+		if _, err := NormalizeYAML(input, false); err == nil {
+			t.Errorf("NormalizeYAML(%q) should return an error", input)
+		}
+	}
+}
+
+func TestRefineContract(t *testing.T) {
+	input := `# contract
+workload:
+  type: workload # workload section
+  compose:
+    archive: abc
+env:
+  type: env
+  logging:
+    logRouter:
+      hostname: example.com
+attestationPublicKey: key
+`
+
+	output, err := RefineContract(input)
+	if err != nil {
+		t.Fatalf("RefineContract() failed: %v", err)
+	}
+
+	// Key order is preserved
+	if !(strings.Index(output, "workload:") < strings.Index(output, "env:") &&
+		strings.Index(output, "env:") < strings.Index(output, "attestationPublicKey:")) {
+		t.Errorf("Expected key order to be preserved, got:\n%s", output)
+	}
+	if !strings.Contains(output, "# contract") || !strings.Contains(output, "# workload section") {
+		t.Errorf("Expected comments to be preserved, got:\n%s", output)
+	}
+
+	// Sections are literal strings that can be parsed again
+	var refined map[string]interface{}
+	if err := yaml.Unmarshal([]byte(output), &refined); err != nil {
+		t.Fatalf("Failed to parse output: %v", err)
+	}
+	for _, key := range []string{"workload", "env"} {
+		section, ok := refined[key].(string)
+		if !ok {
+			t.Fatalf("Expected '%s' to be a string, got %T", key, refined[key])
+		}
+		var parsed map[string]interface{}
+		if err := yaml.Unmarshal([]byte(section), &parsed); err != nil {
+			t.Errorf("Section '%s' is not valid YAML: %v", key, err)
+		}
+	}
+}
+
+func TestRefineContract_Deterministic(t *testing.T) {
+	input := "env:\n  type: env\n  b: 1\n  a: 2\nworkload:\n  type: workload\nz: 1\ny: 2\nx: 3\n"
+
+	first, err := RefineContract(input)
+	if err != nil {
+		t.Fatalf("RefineContract() failed: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		output, err := RefineContract(input)
+		if err != nil {
+			t.Fatalf("RefineContract() failed: %v", err)
+		}
+		if output != first {
+			t.Fatalf("Expected identical output across runs, got:\n%s\nand:\n%s", first, output)
+		}
+	}
+}
+
+func TestRefineContract_StringSections(t *testing.T) {
+	input := "workload: hyper-protect-basic.c2VjcmV0.ZGF0YQ==\nenv: |\n    type: env\n"
+
+	output, err := RefineContract(input)
+	if err != nil {
+		t.Fatalf("RefineContract() failed: %v", err)
+	}
+	if output != input {
+		t.Errorf("Expected string sections to pass through untouched, got:\n%s", output)
+	}
+}
+
+func TestRefineContract_Invalid(t *testing.T) {
+	invalid := []string{"key: [unclosed", "- a\n- b\n"}
+
+	for _, input := range invalid {
+		if _, err := RefineContract(input); err == nil {
+			t.Errorf("RefineContract(%q) should return an error", input)
+		}
+	}
+}
+
+func TestRefineContract_MergeKey(t *testing.T) {
+	input := `common: &c
+  type: env
+  logLevel: info
+env:
+  <<: *c
+  logLevel: debug
+workload:
+  type: workload
+`
+
+	output, err := RefineContract(input)
+	if err != nil {
+		t.Fatalf("RefineContract() failed: %v", err)
+	}
+
+	var refined map[string]interface{}
+	if err := yaml.Unmarshal([]byte(output), &refined); err != nil {
+		t.Fatalf("Failed to parse output: %v\n%s", err, output)
+	}
+	env, ok := refined["env"].(string)
+	if !ok {
+		t.Fatalf("Expected 'env' to be a string, got %T", refined["env"])
+	}
+	if strings.Contains(env, "<<") || strings.Contains(env, "*c") {
+		t.Errorf("Expected the merge key to be expanded, got:\n%s", env)
+	}
+
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal([]byte(env), &parsed); err != nil {
+		t.Fatalf("Section 'env' is not valid YAML: %v", err)
+	}
+	if parsed["type"] != "env" || parsed["logLevel"] != "debug" {
+		t.Errorf("Expected merged keys with explicit keys taking precedence, got %v", parsed)
+	}
+}
+
+func TestRefineContract_AnchoredSection(t *testing.T) {
+	input := `workload: &w
+  type: workload
+  compose:
+    archive: abc
+env:
+  type: env
+copy: *w
+`
+
+	output, err := RefineContract(input)
+	if err != nil {
+		t.Fatalf("RefineContract() failed: %v", err)
+	}
+
+	var refined map[string]interface{}
+	if err := yaml.Unmarshal([]byte(output), &refined); err != nil {
+		t.Fatalf("Output is not valid YAML: %v\n%s", err, output)
+	}
+	workload, ok := refined["workload"].(string)
+	if !ok {
+		t.Fatalf("Expected 'workload' to be a string, got %T", refined["workload"])
+	}
+	if strings.Contains(workload, "&w") {
+		t.Errorf("Expected the anchor to be removed from the section, got:\n%s", workload)
+	}
+	copied, ok := refined["copy"].(map[string]interface{})
+	if !ok || copied["type"] != "workload" {
+		t.Errorf("Expected the alias of the section to be expanded, got %v", refined["copy"])
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	// CompressionNone leaves the payload untouched.
+	CompressionNone = "none"
+	// CompressionGzip compresses the payload with gzip before it is encoded or encrypted.
+	CompressionGzip = "gzip"
+)
+
+// Compress compresses the input with the given compression mode. An empty
+// mode is treated as CompressionNone.
+func Compress(input []byte, mode string) ([]byte, error) {
+	switch mode {
+	case "", CompressionNone:
+		return input, nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		writer, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip writer: %v", err)
+		}
+		if _, err := writer.Write(input); err != nil {
+			return nil, fmt.Errorf("failed to compress payload: %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress payload: %v", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q, expected one of %q, %q", mode, CompressionNone, CompressionGzip)
+	}
+}
+
+// CompressionRatio returns the ratio of the original size to the compressed size.
+func CompressionRatio(originalSize, compressedSize int) float64 {
+	if compressedSize == 0 {
+		return 1
+	}
+	return float64(originalSize) / float64(compressedSize)
+}
+
+// gzipSupport maps a platform to the first runtime version that decompresses
+// gzip compressed contract sections. Only releases whose documentation confirms
+// the support are listed. None does so far, so compressed sections are
+// rejected on every platform until an entry is added here.
+var gzipSupport = map[string]string{}
+
+// ValidateCompressionSupport checks that the runtime identified by platform and
+// version is able to decompress payloads compressed with mode. An empty version
+// refers to the latest runtime.
+func ValidateCompressionSupport(mode, platform, version string) error {
+	if mode == "" || mode == CompressionNone {
+		return nil
+	}
+	if mode != CompressionGzip {
+		return fmt.Errorf("unsupported compression %q, expected one of %q, %q", mode, CompressionNone, CompressionGzip)
+	}
+
+	if platform == "" {
+		platform = DefaultPlatform
+	}
+	minimum, ok := gzipSupport[platform]
+	if !ok {
+		return fmt.Errorf("no release of platform %q is documented to decompress %s compressed sections", platform, mode)
+	}
+	if version == "" {
+		return nil
+	}
+
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Errorf("invalid version %q: %v", version, err)
+	}
+	if current.LessThan(semver.MustParse(minimum)) {
+		return fmt.Errorf("platform %q version %s cannot decompress %s compressed sections, version %s or later is required", platform, version, mode, minimum)
+	}
+
+	return nil
+}
+
+// GenerateTgzBase64 archives the contents of folderPath into a tar.gz file
+// compressed at the given gzip level and returns it base64 encoded.
+func GenerateTgzBase64(folderPath string, level int) (string, error) {
+	info, err := os.Stat(folderPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read folder %s: %v", folderPath, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a folder", folderPath)
+	}
+
+	var buf bytes.Buffer
+	gzipWriter, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return "", fmt.Errorf("invalid compression level %d: %v", level, err)
+	}
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = filepath.WalkDir(folderPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(folderPath, path)
+		if err != nil || relPath == "." {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to archive folder %s: %v", folderPath, err)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize archive: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize archive: %v", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompress_None(t *testing.T) {
+	input := []byte("hello world")
+
+	for _, mode := range []string{"", CompressionNone} {
+		output, err := Compress(input, mode)
+		if err != nil {
+			t.Fatalf("Compress(%q) failed: %v", mode, err)
+		}
+		if !bytes.Equal(output, input) {
+			t.Errorf("Compress(%q) should return the input unchanged", mode)
+		}
+	}
+}
+
+func TestCompress_Gzip(t *testing.T) {
+	input := []byte(strings.Repeat("hello world\n", 100))
+
+	output, err := Compress(input, CompressionGzip)
+	if err != nil {
+		t.Fatalf("Compress() failed: %v", err)
+	}
+	if len(output) >= len(input) {
+		t.Errorf("Expected compressed output to be smaller than %d bytes, got %d", len(input), len(output))
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(output))
+	if err != nil {
+		t.Fatalf("Failed to open gzip output: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress output: %v", err)
+	}
+	if !bytes.Equal(decompressed, input) {
+		t.Error("Decompressed output does not match the input")
+	}
+}
+
+func TestCompress_Unsupported(t *testing.T) {
+	if _, err := Compress([]byte("hello"), "zstd"); err == nil {
+		t.Error("Compress() should fail for an unsupported compression")
+	}
+}
+
+func TestCompressionRatio(t *testing.T) {
+	if ratio := CompressionRatio(100, 25); ratio != 4 {
+		t.Errorf("Expected ratio 4, got %v", ratio)
+	}
+	if ratio := CompressionRatio(0, 0); ratio != 1 {
+		t.Errorf("Expected ratio 1 for empty payloads, got %v", ratio)
+	}
+}
+
+func TestGenerateTgzBase64(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(folder, "compose"), 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "compose", "docker-compose.yaml"), []byte("services: {}\n"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	encoded, err := GenerateTgzBase64(folder, gzip.BestCompression)
+	if err != nil {
+		t.Fatalf("GenerateTgzBase64() failed: %v", err)
+	}
+
+	archive, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("Output is not valid base64: %v", err)
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("Output is not a gzip archive: %v", err)
+	}
+
+	var names []string
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read tar archive: %v", err)
+		}
+		names = append(names, header.Name)
+	}
+
+	expected := []string{"compose/", "compose/docker-compose.yaml"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected archive entries %v, got %v", expected, names)
+	}
+}
+
+func TestGenerateTgzBase64_InvalidLevel(t *testing.T) {
+	if _, err := GenerateTgzBase64(t.TempDir(), 42); err == nil {
+		t.Error("GenerateTgzBase64() should fail for an invalid compression level")
+	}
+}
+
+func TestGenerateTgzBase64_MissingFolder(t *testing.T) {
+	if _, err := GenerateTgzBase64("/path/to/nonexistent/folder", gzip.DefaultCompression); err == nil {
+		t.Error("GenerateTgzBase64() should fail for a missing folder")
+	}
+}
+
+func TestValidateCompressionSupport(t *testing.T) {
+	for _, mode := range []string{"", CompressionNone} {
+		if err := ValidateCompressionSupport(mode, "hpvs", "1.0.23"); err != nil {
+			t.Errorf("Expected uncompressed sections to be accepted: %v", err)
+		}
+	}
+	if err := ValidateCompressionSupport("zstd", "hpvs", ""); err == nil {
+		t.Error("Expected an error for an unsupported compression")
+	}
+	for _, platform := range PlatformNames() {
+		if err := ValidateCompressionSupport(CompressionGzip, platform, ""); err == nil {
+			t.Errorf("Expected gzip to be rejected on platform %s without a documented release", platform)
+		}
+	}
+}
+
+func TestValidateCompressionSupport_Table(t *testing.T) {
+	saved := gzipSupport
+	t.Cleanup(func() { gzipSupport = saved })
+	gzipSupport = map[string]string{"hpvs": "2.0.0"}
+
+	tests := []struct {
+		platform string
+		version  string
+		valid    bool
+	}{
+		{"", "", true},
+		{"hpvs", "2.0.0", true},
+		{"hpvs", "2.1", true},
+		{"hpvs", "1.0.23", false},
+		{"hpvs", "latest", false},
+		{"ccrt", "2.0.0", false},
+	}
+	for _, tt := range tests {
+		err := ValidateCompressionSupport(CompressionGzip, tt.platform, tt.version)
+		if tt.valid && err != nil {
+			t.Errorf("Expected platform %q version %q to decompress gzip: %v", tt.platform, tt.version, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("Expected platform %q version %q to be rejected", tt.platform, tt.version)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EncryptedPrefix is the prefix of contract sections encrypted with the hyper-protect-basic scheme.
+	EncryptedPrefix = "hyper-protect-basic."
+
+	// passwordEnv is the environment variable used to hand the private key password to OpenSSL.
+	passwordEnv = "HPCR_PRIVATE_KEY_PASSWORD"
+)
+
+// encryptableSections lists the contract sections that are encrypted, in the
+// order in which they are processed.
+var encryptableSections = []string{"workload", "env", "attestationPublicKey"}
+
+// IsEncryptedSection reports whether a contract section value is already
+// encrypted with the hyper-protect-basic scheme.
+func IsEncryptedSection(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), EncryptedPrefix)
+}
+
+// EncryptedSections returns the names of the contract sections that are
+// already encrypted. The contract is expected in the form produced by
+// RefineContract.
+func EncryptedSections(contractYAML string) ([]string, error) {
+	root, err := contractMapping(contractYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []string
+	for _, name := range encryptableSections {
+		value := mappingValue(root, name)
+		if value != nil && value.Kind == yaml.ScalarNode && IsEncryptedSection(value.Value) {
+			sections = append(sections, name)
+		}
+	}
+
+	return sections, nil
+}
+
+// AssembleContract builds a signed and encrypted contract in which some
+// sections are already encrypted. Plaintext sections are encrypted with the
+// encrypt function, while encrypted sections are kept as they are. The public
+// key of privKey is added to a plaintext env section as signingKey, and
+// envWorkloadSignature is computed over the final workload and env ciphertexts.
+func AssembleContract(contractYAML string, encrypt func(string) (string, error), privKey, password string) (string, error) {
+	root, err := contractMapping(contractYAML)
+	if err != nil {
+		return "", err
+	}
+
+	workload := mappingValue(root, "workload")
+	env := mappingValue(root, "env")
+	if workload == nil || env == nil {
+		return "", fmt.Errorf("contract must contain both workload and env sections")
+	}
+
+	if env.Kind == yaml.ScalarNode && !IsEncryptedSection(env.Value) {
+		publicKey, err := PublicKeyFromPrivateKey(privKey, password)
+		if err != nil {
+			return "", err
+		}
+		signedEnv, err := addSigningKey(env.Value, publicKey)
+		if err != nil {
+			return "", err
+		}
+		env.Value = signedEnv
+	}
+
+	for _, name := range encryptableSections {
+		value := mappingValue(root, name)
+		if value == nil {
+			continue
+		}
+		if value.Kind != yaml.ScalarNode {
+			return "", fmt.Errorf("section %s must be a string", name)
+		}
+		if !IsEncryptedSection(value.Value) {
+			encrypted, err := encrypt(value.Value)
+			if err != nil {
+				return "", fmt.Errorf("failed to encrypt section %s: %v", name, err)
+			}
+			value.Value = encrypted
+		}
+		value.Value = strings.TrimSpace(value.Value)
+		value.Style = 0
+		value.Tag = "!!str"
+	}
+
+	signature, err := SignData(workload.Value+env.Value, privKey, password)
+	if err != nil {
+		return "", err
+	}
+
+	signatureNode := mappingValue(root, "envWorkloadSignature")
+	if signatureNode == nil {
+		signatureNode = &yaml.Node{Kind: yaml.ScalarNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "envWorkloadSignature"}, signatureNode)
+	}
+	signatureNode.Kind = yaml.ScalarNode
+	signatureNode.Tag = "!!str"
+	signatureNode.Value = signature
+
+	resultBytes, err := yaml.Marshal(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract: %v", err)
+	}
+
+	return string(resultBytes), nil
+}
+
+// PublicKeyFromPrivateKey returns the PEM encoded public key of a private key
+// using OpenSSL. It respects the OPENSSL_BIN environment variable for the
+// OpenSSL binary path.
+func PublicKeyFromPrivateKey(privKey, password string) (string, error) {
+	output, err := runWithPrivateKey(privKey, password, nil, "pkey", "-pubout")
+	if err != nil {
+		return "", fmt.Errorf("failed to derive public key: %v", err)
+	}
+
+	return string(output), nil
+}
+
+// SignData signs the SHA256 digest of data with a private key using OpenSSL
+// and returns the base64 encoded signature.
+func SignData(data, privKey, password string) (string, error) {
+	output, err := runWithPrivateKey(privKey, password, strings.NewReader(data), "dgst", "-sha256", "-sign")
+	if err != nil {
+		return "", fmt.Errorf("failed to sign data: %v", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(output), nil
+}
+
+// runWithPrivateKey runs an OpenSSL command on a private key that is written to
+// a temporary file. The key file path is appended to args, or passed with -in
+// for the pkey command.
+func runWithPrivateKey(privKey, password string, stdin *strings.Reader, args ...string) ([]byte, error) {
+	opensslBin := os.Getenv("OPENSSL_BIN")
+	if opensslBin == "" {
+		opensslBin = "openssl"
+	}
+
+	keyFile, err := os.CreateTemp("", "hpcr-key-*.pem")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary key file: %v", err)
+	}
+	defer os.Remove(keyFile.Name())
+
+	if _, err := keyFile.WriteString(privKey); err != nil {
+		keyFile.Close()
+		return nil, fmt.Errorf("failed to write temporary key file: %v", err)
+	}
+	if err := keyFile.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write temporary key file: %v", err)
+	}
+
+	if args[0] == "pkey" {
+		args = append(args, "-in", keyFile.Name())
+	} else {
+		args = append(args, keyFile.Name())
+	}
+	if password != "" {
+		args = append(args, "-passin", "env:"+passwordEnv)
+	}
+
+	cmd := exec.Command(opensslBin, args...)
+	cmd.Env = append(os.Environ(), passwordEnv+"="+password)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%v, stderr: %s", err, stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// addSigningKey adds the signingKey entry to a plaintext env section.
+func addSigningKey(envYAML, publicKey string) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(envYAML), &document); err != nil {
+		return "", fmt.Errorf("failed to unmarshal env section: %v", err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("env section must be a YAML mapping")
+	}
+
+	env := document.Content[0]
+	signingKey := mappingValue(env, "signingKey")
+	if signingKey == nil {
+		signingKey = &yaml.Node{Kind: yaml.ScalarNode}
+		env.Content = append(env.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "signingKey"}, signingKey)
+	}
+	signingKey.Kind = yaml.ScalarNode
+	signingKey.Tag = "!!str"
+	signingKey.Style = yaml.LiteralStyle
+	signingKey.Value = publicKey
+
+	envBytes, err := yaml.Marshal(&document)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal env section: %v", err)
+	}
+
+	return string(envBytes), nil
+}
+
+// MergeContractEnv deep merges the YAML mapping overrides into the env section
+// of a contract. Nested mappings are merged key by key, all other values of
+// overrides replace the values of the contract. Key order and comments of the
+// contract are kept. The env section must be in plaintext, an empty overrides
+// document returns the contract as it is.
+func MergeContractEnv(contractYAML, overrides string) (string, error) {
+	var overridesDocument yaml.Node
+	if err := yaml.Unmarshal([]byte(overrides), &overridesDocument); err != nil {
+		return "", fmt.Errorf("failed to unmarshal env overrides: %v", err)
+	}
+	if len(overridesDocument.Content) == 0 {
+		return contractYAML, nil
+	}
+	overridesRoot := overridesDocument.Content[0]
+	if overridesRoot.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("env overrides must be a YAML mapping")
+	}
+
+	root, err := contractMapping(contractYAML)
+	if err != nil {
+		return "", err
+	}
+
+	merged := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "env" {
+			continue
+		}
+		env := resolveAlias(root.Content[i+1])
+		switch {
+		case env.Kind == yaml.MappingNode:
+			root.Content[i+1] = mergeYAML(env, overridesRoot)
+		case env.Kind == yaml.ScalarNode && env.ShortTag() == "!!null":
+			root.Content[i+1] = overridesRoot
+		default:
+			return "", fmt.Errorf("env section must be a plaintext YAML mapping to apply overrides")
+		}
+		merged = true
+	}
+	if !merged {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "env"}, overridesRoot)
+	}
+
+	resultBytes, err := yaml.Marshal(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract: %v", err)
+	}
+
+	return string(resultBytes), nil
+}
+
+// mergeYAML returns the deep merge of two nodes without modifying either of
+// them. Mappings are merged key by key, otherwise override wins.
+func mergeYAML(base, override *yaml.Node) *yaml.Node {
+	base, override = resolveAlias(base), resolveAlias(override)
+	if base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		return override
+	}
+
+	merged := *base
+	merged.Content = append([]*yaml.Node(nil), base.Content...)
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+		found := false
+		for j := 0; j+1 < len(merged.Content); j += 2 {
+			if merged.Content[j].Value == key.Value {
+				merged.Content[j+1] = mergeYAML(merged.Content[j+1], value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+
+	return &merged
+}
+
+// resolveAlias returns a copy of the node an alias refers to, without its
+// anchor, or the node itself if it is not an alias.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	if node.Kind != yaml.AliasNode || node.Alias == nil {
+		return node
+	}
+	resolved := *node.Alias
+	resolved.Anchor = ""
+	return &resolved
+}
+
+// contractMapping parses a contract and returns its top level mapping node.
+func contractMapping(contractYAML string) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(contractYAML), &document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract: %v", err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("contract must be a YAML mapping")
+	}
+
+	return document.Content[0], nil
+}
+
+// mappingValue returns the value node stored under key in a mapping node, or
+// nil if the key is not present.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
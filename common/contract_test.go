@@ -0,0 +1,204 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fakeEncrypt mimics the hyper-protect-basic format without a certificate.
+func fakeEncrypt(text string) (string, error) {
+	return EncryptedPrefix + "a2V5." + base64.StdEncoding.EncodeToString([]byte(text)), nil
+}
+
+func TestIsEncryptedSection(t *testing.T) {
+	if !IsEncryptedSection("hyper-protect-basic.a2V5.ZGF0YQ==") {
+		t.Error("Expected hyper-protect-basic value to be reported as encrypted")
+	}
+	if IsEncryptedSection("type: workload\n") {
+		t.Error("Expected plaintext value not to be reported as encrypted")
+	}
+}
+
+func TestEncryptedSections(t *testing.T) {
+	input := "workload: hyper-protect-basic.a2V5.ZGF0YQ==\nenv: |\n    type: env\n"
+
+	sections, err := EncryptedSections(input)
+	if err != nil {
+		t.Fatalf("EncryptedSections() failed: %v", err)
+	}
+	if len(sections) != 1 || sections[0] != "workload" {
+		t.Errorf("Expected [workload], got %v", sections)
+	}
+}
+
+func TestAssembleContract(t *testing.T) {
+	privKey, err := GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey() failed: %v", err)
+	}
+
+	workload := "hyper-protect-basic.aXN2.d29ya2xvYWQ="
+	input := "workload: " + workload + "\nenv: |\n    type: env\n"
+
+	output, err := AssembleContract(input, fakeEncrypt, privKey, "")
+	if err != nil {
+		t.Fatalf("AssembleContract() failed: %v", err)
+	}
+
+	var result map[string]string
+	if err := yaml.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to parse output: %v", err)
+	}
+
+	// The pre-encrypted workload is kept as it is
+	if result["workload"] != workload {
+		t.Errorf("Expected workload to pass through, got '%s'", result["workload"])
+	}
+
+	// The plaintext env is encrypted and carries the signing key
+	if !IsEncryptedSection(result["env"]) {
+		t.Fatalf("Expected env to be encrypted, got '%s'", result["env"])
+	}
+	envBytes, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(result["env"], EncryptedPrefix+"a2V5."))
+	if err != nil {
+		t.Fatalf("Failed to decode env: %v", err)
+	}
+	if !strings.Contains(string(envBytes), "signingKey:") || !strings.Contains(string(envBytes), "PUBLIC KEY") {
+		t.Errorf("Expected env to contain the signing key, got:\n%s", string(envBytes))
+	}
+
+	// The signature covers the final workload and env ciphertexts
+	block, _ := pem.Decode([]byte(privKey))
+	if block == nil {
+		t.Fatal("Failed to decode private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+	rsaKey := parsed.(*rsa.PrivateKey)
+
+	signature, err := base64.StdEncoding.DecodeString(result["envWorkloadSignature"])
+	if err != nil {
+		t.Fatalf("Failed to decode signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(result["workload"] + result["env"]))
+	if err := rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("Signature verification failed: %v", err)
+	}
+}
+
+func TestAssembleContract_MissingSection(t *testing.T) {
+	input := "workload: hyper-protect-basic.aXN2.d29ya2xvYWQ=\n"
+
+	if _, err := AssembleContract(input, fakeEncrypt, "", ""); err == nil {
+		t.Error("AssembleContract() should fail without an env section")
+	}
+}
+
+func TestMergeContractEnv(t *testing.T) {
+	contract := "# base contract\nenv:\n  type: env\n  env:\n    LOG_LEVEL: info\n    REGION: us-east\nworkload: hyper-protect-basic.a2V5.ZGF0YQ==\n"
+	overrides := "env:\n  LOG_LEVEL: debug\n  INSTANCE: node-1\n"
+
+	result, err := MergeContractEnv(contract, overrides)
+	if err != nil {
+		t.Fatalf("MergeContractEnv() failed: %v", err)
+	}
+
+	var parsed struct {
+		Env struct {
+			Type string            `yaml:"type"`
+			Env  map[string]string `yaml:"env"`
+		} `yaml:"env"`
+		Workload string `yaml:"workload"`
+	}
+	if err := yaml.Unmarshal([]byte(result), &parsed); err != nil {
+		t.Fatalf("Failed to parse merged contract: %v", err)
+	}
+	if parsed.Env.Type != "env" {
+		t.Errorf("Expected type env, got %q", parsed.Env.Type)
+	}
+	expected := map[string]string{"LOG_LEVEL": "debug", "REGION": "us-east", "INSTANCE": "node-1"}
+	for key, value := range expected {
+		if parsed.Env.Env[key] != value {
+			t.Errorf("Expected %s=%s, got %q", key, value, parsed.Env.Env[key])
+		}
+	}
+	if parsed.Workload != "hyper-protect-basic.a2V5.ZGF0YQ==" {
+		t.Errorf("Expected workload to be unchanged, got %q", parsed.Workload)
+	}
+	if !strings.HasPrefix(result, "# base contract") {
+		t.Errorf("Expected comments to be kept, got %q", result)
+	}
+	if !strings.Contains(contract, "LOG_LEVEL: info") {
+		t.Errorf("Expected input contract to be unchanged")
+	}
+}
+
+func TestMergeContractEnv_EmptyOverrides(t *testing.T) {
+	contract := "env:\n  type: env\n"
+
+	result, err := MergeContractEnv(contract, "")
+	if err != nil {
+		t.Fatalf("MergeContractEnv() failed: %v", err)
+	}
+	if result != contract {
+		t.Errorf("Expected contract to be unchanged, got %q", result)
+	}
+}
+
+func TestMergeContractEnv_MissingEnv(t *testing.T) {
+	result, err := MergeContractEnv("workload:\n  type: workload\n", "type: env\n")
+	if err != nil {
+		t.Fatalf("MergeContractEnv() failed: %v", err)
+	}
+	if !strings.Contains(result, "env:\n    type: env") {
+		t.Errorf("Expected env section to be added, got %q", result)
+	}
+}
+
+func TestMergeContractEnv_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		contract  string
+		overrides string
+	}{
+		{"encrypted env", "env: hyper-protect-basic.a2V5.ZGF0YQ==\n", "type: env\n"},
+		{"overrides not a mapping", "env:\n  type: env\n", "- item\n"},
+		{"invalid overrides", "env:\n  type: env\n", "key: [unclosed"},
+		{"contract not a mapping", "- item\n", "type: env\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MergeContractEnv(tt.contract, tt.overrides); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
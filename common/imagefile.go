@@ -0,0 +1,180 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// hashChunkSize is the size of the chunks in which image files are read.
+const hashChunkSize = 4 * 1024 * 1024
+
+// bsdChecksumLine matches checksum lines in BSD format, e.g. "SHA256 (file) = <hex>".
+var bsdChecksumLine = regexp.MustCompile(`^SHA256 \((.+)\) = ([0-9a-fA-F]{64})$`)
+
+// HashFile computes the hex encoded SHA256 checksum of a file without loading
+// it into memory. The optional progress callback is invoked after every chunk
+// with the number of bytes hashed so far and the size of the file.
+func HashFile(path string, progress func(done, total int64)) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat %s: %v", path, err)
+	}
+	total := info.Size()
+
+	hash := sha256.New()
+	buffer := make([]byte, hashChunkSize)
+	var done int64
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			hash.Write(buffer[:n])
+			done += int64(n)
+			if progress != nil {
+				progress(done, total)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), done, nil
+}
+
+// ParseChecksumFile parses SHA256 checksums in the format of sha256sum
+// ("<hex>  <file>") or in BSD format ("SHA256 (<file>) = <hex>") and returns
+// them keyed by file name. Entries whose file names share the same base name
+// are rejected, since the checksum to verify against would be ambiguous.
+func ParseChecksumFile(content string) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var checksum, name string
+		if matches := bsdChecksumLine.FindStringSubmatch(line); matches != nil {
+			name, checksum = matches[1], matches[2]
+		} else {
+			// the file name is everything after the first run of whitespace,
+			// so that names containing spaces are kept intact
+			separator := strings.IndexAny(line, " \t")
+			if separator < 0 {
+				return nil, fmt.Errorf("invalid checksum line %d: %q", i+1, line)
+			}
+			checksum = line[:separator]
+			name = strings.TrimPrefix(strings.TrimLeft(line[separator:], " \t"), "*")
+			if name == "" {
+				return nil, fmt.Errorf("invalid checksum line %d: %q", i+1, line)
+			}
+			if algorithm, err := DigestAlgorithm(checksum); err != nil || algorithm != DigestSHA256 {
+				return nil, fmt.Errorf("invalid SHA256 checksum on line %d: %q", i+1, line)
+			}
+		}
+
+		base := filepath.Base(name)
+		if _, ok := checksums[base]; ok {
+			return nil, fmt.Errorf("duplicate checksum for %s on line %d", base, i+1)
+		}
+		checksums[base] = strings.ToLower(checksum)
+	}
+
+	if len(checksums) == 0 {
+		return nil, fmt.Errorf("no checksums found")
+	}
+
+	return checksums, nil
+}
+
+// VerifySignature verifies a signature created over data with the private key
+// of the PEM encoded certificate, using SHA256 with RSA or ECDSA.
+func VerifySignature(data, signature []byte, certPEM string) error {
+	certs, err := ParseCertificates(certPEM)
+	if err != nil {
+		return err
+	}
+	cert := certs[0]
+
+	var algorithm x509.SignatureAlgorithm
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algorithm = x509.SHA256WithRSA
+	case *ecdsa.PublicKey:
+		algorithm = x509.ECDSAWithSHA256
+	default:
+		return fmt.Errorf("unsupported public key type %T", cert.PublicKey)
+	}
+
+	if err := cert.CheckSignature(algorithm, data, signature); err != nil {
+		return fmt.Errorf("signature verification failed: %v", err)
+	}
+
+	return nil
+}
+
+// ImageVersionFromFileName derives the version of an HPCR image from its file
+// name, e.g. "ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2" yields "1.0.20".
+func ImageVersionFromFileName(path string) (string, error) {
+	name := filepath.Base(path)
+	if index := strings.Index(name, "."); index >= 0 {
+		name = name[:index]
+	}
+
+	matches := hpcrImageName.FindStringSubmatch(name)
+	if matches == nil {
+		return "", fmt.Errorf("file name %s does not follow the HPCR image naming scheme", filepath.Base(path))
+	}
+
+	return fmt.Sprintf("%s.%s.%s", matches[1], matches[2], matches[3]), nil
+}
+
+// ImageVersionFromManifest reads the version of an HPCR image from a JSON
+// manifest with a top level "version" field.
+func ImageVersionFromManifest(manifest string) (string, error) {
+	var parsed struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(manifest), &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %v", err)
+	}
+	if parsed.Version == "" {
+		return "", fmt.Errorf("manifest does not contain a version")
+	}
+
+	return parsed.Version, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.qcow2")
+	if err := os.WriteFile(path, []byte("hello world"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var lastDone, lastTotal int64
+	checksum, size, err := HashFile(path, func(done, total int64) {
+		lastDone, lastTotal = done, total
+	})
+	if err != nil {
+		t.Fatalf("HashFile() failed: %v", err)
+	}
+	if checksum != "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" {
+		t.Errorf("Unexpected checksum %s", checksum)
+	}
+	if size != 11 || lastDone != 11 || lastTotal != 11 {
+		t.Errorf("Unexpected size %d or progress %d/%d", size, lastDone, lastTotal)
+	}
+
+	if _, _, err := HashFile(filepath.Join(t.TempDir(), "missing.qcow2"), nil); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestParseChecksumFile(t *testing.T) {
+	content := `# checksums
+b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2
+SHA256 (images/other.qcow2) = A94D27B9934D3E08A52E52D7DA7DABFAC484EFE37A5380EE9088F7ACE2EFCDE9
+c94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9 *binary.qcow2
+d94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  image with spaces.qcow2
+`
+	checksums, err := ParseChecksumFile(content)
+	if err != nil {
+		t.Fatalf("ParseChecksumFile() failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2": "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		"other.qcow2":             "a94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		"binary.qcow2":            "c94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		"image with spaces.qcow2": "d94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+	}
+	for name, checksum := range expected {
+		if checksums[name] != checksum {
+			t.Errorf("Expected checksum %s for %s, got %s", checksum, name, checksums[name])
+		}
+	}
+
+	duplicate := `b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  s390x/image.qcow2
+c94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  amd64/image.qcow2
+`
+	for _, invalid := range []string{"", "abc  file.qcow2", "not a checksum line at all", "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", duplicate} {
+		if _, err := ParseChecksumFile(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	signer := newTestCert(t, "HPCR Image Signing", 1, time.Now().Add(time.Hour), nil)
+	data := []byte("b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9  image.qcow2\n")
+
+	digest := sha256.Sum256(data)
+	signature, err := ecdsa.SignASN1(rand.Reader, signer.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifySignature(data, signature, signer.pem); err != nil {
+		t.Errorf("VerifySignature() failed: %v", err)
+	}
+	if err := VerifySignature([]byte("tampered"), signature, signer.pem); err == nil {
+		t.Error("Expected an error for tampered data")
+	}
+
+	other := newTestCert(t, "Other", 2, time.Now().Add(time.Hour), nil)
+	if err := VerifySignature(data, signature, other.pem); err == nil {
+		t.Error("Expected an error for a signature of another key")
+	}
+}
+
+func TestImageVersionFromFileName(t *testing.T) {
+	version, err := ImageVersionFromFileName("/images/ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2")
+	if err != nil {
+		t.Fatalf("ImageVersionFromFileName() failed: %v", err)
+	}
+	if version != "1.0.20" {
+		t.Errorf("Expected version 1.0.20, got %s", version)
+	}
+
+	if _, err := ImageVersionFromFileName("ubuntu.qcow2"); err == nil {
+		t.Error("Expected an error for a file name that is not an HPCR image")
+	}
+}
+
+func TestImageVersionFromManifest(t *testing.T) {
+	version, err := ImageVersionFromManifest(`{"name": "hpcr", "version": "1.0.21"}`)
+	if err != nil {
+		t.Fatalf("ImageVersionFromManifest() failed: %v", err)
+	}
+	if version != "1.0.21" {
+		t.Errorf("Expected version 1.0.21, got %s", version)
+	}
+
+	for _, invalid := range []string{"not json", `{"name": "hpcr"}`} {
+		if _, err := ImageVersionFromManifest(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// DefaultArchitecture is the architecture of the Hyper Protect images.
+const DefaultArchitecture = "s390x"
+
+// Defaults of the candidate checks that image.HpcrSelectImage of contract-go
+// applies, used when the image filters are configured in the provider.
+const (
+	DefaultImageStatus     = "available"
+	DefaultImageVisibility = "public"
+	DefaultImageOSPattern  = `^hyper-protect-[\w-]+-s390x-hpcr$`
+)
+
+// DeprecatedImageStatus is the status of images that have been replaced by a newer release.
+const DeprecatedImageStatus = "deprecated"
+
+// hpcrImageName matches the names of HPCR images and captures the components of their version.
+var hpcrImageName = regexp.MustCompile(`^ibm-hyper-protect-container-runtime-(\d+)-(\d+)-s390x-(\d+)$`)
+
+// Image describes an HPCR image from the JSON formatted list of images.
+type Image struct {
+	ID           string
+	Name         string
+	Version      string
+	Sha256       string
+	Status       string
+	Visibility   string
+	Architecture string
+	OS           string
+	CreatedAt    time.Time
+}
+
+// ImageFilter restricts the images considered for selection. Empty fields do not filter.
+type ImageFilter struct {
+	Architecture string
+	// Statuses lists the accepted image statuses.
+	Statuses   []string
+	Visibility string
+	// OSPattern is a regular expression matched against the operating system name.
+	OSPattern string
+	// CreatedAfter excludes images created before this time.
+	CreatedAfter time.Time
+}
+
+// rawImage covers the image formats of the IBM Cloud API, CLI and the ibm_is_images Terraform data source.
+type rawImage struct {
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Status          string          `json:"status"`
+	Visibility      string          `json:"visibility"`
+	Architecture    string          `json:"architecture"`
+	OS              string          `json:"os"`
+	OperatingSystem json.RawMessage `json:"operating_system"`
+	Checksum        string          `json:"checksum"`
+	File            struct {
+		Checksums struct {
+			Sha256 string `json:"sha256"`
+		} `json:"checksums"`
+	} `json:"file"`
+	CreatedAt string `json:"created_at"`
+}
+
+type rawOperatingSystem struct {
+	Name         string `json:"name"`
+	Architecture string `json:"architecture"`
+}
+
+// ParseImages parses a JSON formatted list of images and returns the HPCR
+// images, identified by their name. Other images are ignored.
+func ParseImages(imagesJSON string) ([]Image, error) {
+	var rawImages []rawImage
+	if err := json.Unmarshal([]byte(imagesJSON), &rawImages); err != nil {
+		return nil, fmt.Errorf("failed to parse images: %v", err)
+	}
+
+	var images []Image
+	for _, raw := range rawImages {
+		matches := hpcrImageName.FindStringSubmatch(raw.Name)
+		if matches == nil {
+			continue
+		}
+
+		image := Image{
+			ID:           raw.ID,
+			Name:         raw.Name,
+			Version:      fmt.Sprintf("%s.%s.%s", matches[1], matches[2], matches[3]),
+			Sha256:       raw.Checksum,
+			Status:       raw.Status,
+			Visibility:   raw.Visibility,
+			Architecture: raw.Architecture,
+			OS:           raw.OS,
+		}
+		if image.Sha256 == "" {
+			image.Sha256 = raw.File.Checksums.Sha256
+		}
+
+		operatingSystem, err := parseOperatingSystem(raw.OperatingSystem)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse operating system of image %s: %v", raw.Name, err)
+		}
+		if image.OS == "" {
+			image.OS = operatingSystem.Name
+		}
+		if image.Architecture == "" {
+			image.Architecture = operatingSystem.Architecture
+		}
+
+		if raw.CreatedAt != "" {
+			createdAt, err := time.Parse(time.RFC3339, raw.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid creation date of image %s: %v", raw.Name, err)
+			}
+			image.CreatedAt = createdAt
+		}
+
+		images = append(images, image)
+	}
+
+	return images, nil
+}
+
+// parseOperatingSystem reads the operating system, which is an object in the
+// IBM Cloud API and a single element list in the Terraform data source.
+func parseOperatingSystem(raw json.RawMessage) (rawOperatingSystem, error) {
+	var operatingSystem rawOperatingSystem
+	if len(raw) == 0 || string(raw) == "null" {
+		return operatingSystem, nil
+	}
+
+	if raw[0] == '[' {
+		var list []rawOperatingSystem
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return operatingSystem, err
+		}
+		if len(list) > 0 {
+			operatingSystem = list[0]
+		}
+		return operatingSystem, nil
+	}
+
+	err := json.Unmarshal(raw, &operatingSystem)
+	return operatingSystem, err
+}
+
+// FilterImages returns the images that match the filter.
+func FilterImages(images []Image, filter ImageFilter) ([]Image, error) {
+	var osPattern *regexp.Regexp
+	if filter.OSPattern != "" {
+		pattern, err := regexp.Compile(filter.OSPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operating system pattern %q: %v", filter.OSPattern, err)
+		}
+		osPattern = pattern
+	}
+
+	var filtered []Image
+	for _, image := range images {
+		if filter.Architecture != "" && image.Architecture != filter.Architecture {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, image.Status) {
+			continue
+		}
+		if filter.Visibility != "" && image.Visibility != filter.Visibility {
+			continue
+		}
+		if osPattern != nil && !osPattern.MatchString(image.OS) {
+			continue
+		}
+		if !filter.CreatedAfter.IsZero() && (image.CreatedAt.IsZero() || image.CreatedAt.Before(filter.CreatedAfter)) {
+			continue
+		}
+		filtered = append(filtered, image)
+	}
+
+	return filtered, nil
+}
+
+// SelectImages returns the images whose version matches the semantic version
+// range spec, sorted by version in descending order.
+func SelectImages(images []Image, spec string) ([]Image, error) {
+	constraint, err := semver.NewConstraint(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version range %q: %v", spec, err)
+	}
+
+	type versionedImage struct {
+		image   Image
+		version *semver.Version
+	}
+
+	var matching []versionedImage
+	for _, image := range images {
+		version, err := semver.NewVersion(image.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q of image %s: %v", image.Version, image.Name, err)
+		}
+		if constraint.Check(version) {
+			matching = append(matching, versionedImage{image: image, version: version})
+		}
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].version.GreaterThan(matching[j].version)
+	})
+
+	selected := make([]Image, 0, len(matching))
+	for _, candidate := range matching {
+		selected = append(selected, candidate.image)
+	}
+
+	return selected, nil
+}
+
+// ApplyDeprecationGrace removes the images created within grace before now
+// from images sorted by SelectImages, so that a new release is only selected
+// once it is older than the grace period. The grace period never removes the
+// last match: if every image is within the grace period, the oldest of them
+// is kept.
+func ApplyDeprecationGrace(images []Image, grace time.Duration, now time.Time) []Image {
+	if grace <= 0 || len(images) == 0 {
+		return images
+	}
+
+	var settled []Image
+	for _, image := range images {
+		if image.CreatedAt.IsZero() || !image.CreatedAt.After(now.Add(-grace)) {
+			settled = append(settled, image)
+		}
+	}
+	if len(settled) == 0 {
+		return images[len(images)-1:]
+	}
+
+	return settled
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+const testImages = `[
+  {
+    "id": "r006-old",
+    "name": "ibm-hyper-protect-container-runtime-1-0-s390x-22",
+    "os": "hyper-protect-1-0-s390x-hpcr",
+    "architecture": "s390x",
+    "status": "deprecated",
+    "visibility": "public",
+    "checksum": "aaaa",
+    "created_at": "2025-01-10T00:00:00Z"
+  },
+  {
+    "id": "r006-current",
+    "name": "ibm-hyper-protect-container-runtime-1-0-s390x-23",
+    "operating_system": [{"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}],
+    "status": "available",
+    "visibility": "public",
+    "checksum": "bbbb",
+    "created_at": "2025-06-01T00:00:00Z"
+  },
+  {
+    "id": "r006-new",
+    "name": "ibm-hyper-protect-container-runtime-1-0-s390x-24",
+    "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"},
+    "status": "available",
+    "visibility": "public",
+    "file": {"checksums": {"sha256": "cccc"}},
+    "created_at": "2025-06-20T00:00:00Z"
+  },
+  {
+    "id": "r006-other",
+    "name": "ibm-ubuntu-24-04-minimal-amd64-1",
+    "os": "ubuntu-24-04-amd64",
+    "architecture": "amd64",
+    "status": "available",
+    "visibility": "public"
+  }
+]`
+
+func TestParseImages(t *testing.T) {
+	images, err := ParseImages(testImages)
+	if err != nil {
+		t.Fatalf("ParseImages() failed: %v", err)
+	}
+
+	if len(images) != 3 {
+		t.Fatalf("Expected 3 HPCR images, got %d", len(images))
+	}
+
+	newest := images[2]
+	if newest.Version != "1.0.24" {
+		t.Errorf("Expected version 1.0.24, got %s", newest.Version)
+	}
+	if newest.Sha256 != "cccc" || newest.OS != "hyper-protect-1-0-s390x-hpcr" || newest.Architecture != "s390x" {
+		t.Errorf("Unexpected image fields: %+v", newest)
+	}
+	if images[1].OS != "hyper-protect-1-0-s390x-hpcr" {
+		t.Errorf("Expected operating system from list, got %s", images[1].OS)
+	}
+}
+
+func TestParseImages_Invalid(t *testing.T) {
+	if _, err := ParseImages("not json"); err == nil {
+		t.Error("ParseImages() should fail for invalid JSON")
+	}
+}
+
+func TestFilterImages(t *testing.T) {
+	images, err := ParseImages(testImages)
+	if err != nil {
+		t.Fatalf("ParseImages() failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   ImageFilter
+		expected []string
+	}{
+		{"no filter", ImageFilter{}, []string{"r006-old", "r006-current", "r006-new"}},
+		{"status", ImageFilter{Statuses: []string{"available"}}, []string{"r006-current", "r006-new"}},
+		{"statuses", ImageFilter{Statuses: []string{DefaultImageStatus, DeprecatedImageStatus}}, []string{"r006-old", "r006-current", "r006-new"}},
+		{"os pattern", ImageFilter{OSPattern: "^hyper-protect-.*-hpcr$"}, []string{"r006-old", "r006-current", "r006-new"}},
+		{"created after", ImageFilter{CreatedAfter: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)}, []string{"r006-current", "r006-new"}},
+		{"defaults", ImageFilter{Architecture: DefaultArchitecture, Statuses: []string{DefaultImageStatus}, Visibility: DefaultImageVisibility, OSPattern: DefaultImageOSPattern}, []string{"r006-current", "r006-new"}},
+		{"architecture", ImageFilter{Architecture: "amd64"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, err := FilterImages(images, tt.filter)
+			if err != nil {
+				t.Fatalf("FilterImages() failed: %v", err)
+			}
+			if len(filtered) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %+v", tt.expected, filtered)
+			}
+			for i, id := range tt.expected {
+				if filtered[i].ID != id {
+					t.Errorf("Expected image %d to be %s, got %s", i, id, filtered[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterImages_InvalidPattern(t *testing.T) {
+	if _, err := FilterImages(nil, ImageFilter{OSPattern: "["}); err == nil {
+		t.Error("FilterImages() should fail for an invalid pattern")
+	}
+}
+
+func TestSelectImages(t *testing.T) {
+	images, err := ParseImages(testImages)
+	if err != nil {
+		t.Fatalf("ParseImages() failed: %v", err)
+	}
+
+	selected, err := SelectImages(images, "<1.0.24")
+	if err != nil {
+		t.Fatalf("SelectImages() failed: %v", err)
+	}
+	if len(selected) != 2 || selected[0].Version != "1.0.23" || selected[1].Version != "1.0.22" {
+		t.Errorf("Unexpected selection: %+v", selected)
+	}
+
+	if _, err := SelectImages(images, "not a range"); err == nil {
+		t.Error("SelectImages() should fail for an invalid version range")
+	}
+}
+
+func TestApplyDeprecationGrace(t *testing.T) {
+	images, err := ParseImages(testImages)
+	if err != nil {
+		t.Fatalf("ParseImages() failed: %v", err)
+	}
+	now := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		spec      string
+		graceDays int
+		expected  []string
+	}{
+		{"newest within grace", "*", 20, []string{"r006-current", "r006-old"}},
+		{"exact spec within grace", "1.0.24", 20, []string{"r006-new"}},
+		{"single image within grace", ">=1.0.24", 20, []string{"r006-new"}},
+		{"all within grace keeps oldest", ">=1.0.23", 60, []string{"r006-current"}},
+		{"no grace", "*", 0, []string{"r006-new", "r006-current", "r006-old"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := SelectImages(images, tt.spec)
+			if err != nil {
+				t.Fatalf("SelectImages() failed: %v", err)
+			}
+			selected = ApplyDeprecationGrace(selected, time.Duration(tt.graceDays)*24*time.Hour, now)
+			if len(selected) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %+v", tt.expected, selected)
+			}
+			for i, id := range tt.expected {
+				if selected[i].ID != id {
+					t.Errorf("Expected image %d to be %s, got %s", i, id, selected[i].ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// InitDataAnnotation is the pod annotation that carries the initdata of a confidential container.
+	InitDataAnnotation = "io.katacontainers.config.runtime.cc_init_data"
+	// InitDataVersion is the version of the initdata format.
+	InitDataVersion = "0.1.0"
+	// DefaultInitDataContractKey is the key of the contract in the data table of the initdata.
+	DefaultInitDataContractKey = "user-data"
+)
+
+// initDataHashes maps the supported initdata algorithms to their hash functions.
+var initDataHashes = map[string]func() hash.Hash{
+	DigestSHA256: sha256.New,
+	DigestSHA384: sha512.New384,
+	DigestSHA512: sha512.New,
+}
+
+// InitDataTOML renders the initdata document of a confidential container with
+// the entries of data in its data table, sorted by key.
+func InitDataTOML(algorithm string, data map[string]string) (string, error) {
+	if _, ok := initDataHashes[algorithm]; !ok {
+		return "", fmt.Errorf("unsupported initdata algorithm %q, expected one of %q, %q, %q", algorithm, DigestSHA256, DigestSHA384, DigestSHA512)
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var toml strings.Builder
+	fmt.Fprintf(&toml, "algorithm = %s\n", strconv.Quote(algorithm))
+	fmt.Fprintf(&toml, "version = %s\n", strconv.Quote(InitDataVersion))
+	toml.WriteString("\n[data]\n")
+	for _, key := range keys {
+		fmt.Fprintf(&toml, "%s = %s\n", tomlBasicString(key), tomlString(data[key]))
+	}
+
+	return toml.String(), nil
+}
+
+// tomlString renders a value as multi-line literal string, or as basic string
+// if the value cannot be represented literally.
+func tomlString(value string) string {
+	if tomlLiteral(value) {
+		return "'''\n" + value + "'''"
+	}
+	return tomlBasicString(value)
+}
+
+// tomlLiteral reports whether a value can be written as multi-line literal
+// string, which must not contain control characters other than tab and line
+// feed, nor end with or contain its delimiter.
+func tomlLiteral(value string) bool {
+	if strings.Contains(value, "'''") || strings.HasSuffix(value, "'") {
+		return false
+	}
+	for _, char := range value {
+		if (char < 0x20 && char != '\t' && char != '\n') || char == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlBasicString renders a value as basic string with escape sequences.
+func tomlBasicString(value string) string {
+	var basic strings.Builder
+	basic.WriteByte('"')
+	for _, char := range value {
+		switch char {
+		case '"':
+			basic.WriteString(`\"`)
+		case '\\':
+			basic.WriteString(`\\`)
+		case '\n':
+			basic.WriteString(`\n`)
+		case '\r':
+			basic.WriteString(`\r`)
+		case '\t':
+			basic.WriteString(`\t`)
+		default:
+			if char < 0x20 || char == 0x7f {
+				fmt.Fprintf(&basic, `\u%04X`, char)
+			} else {
+				basic.WriteRune(char)
+			}
+		}
+	}
+	basic.WriteByte('"')
+	return basic.String()
+}
+
+// InitDataAnnotationValue encodes an initdata document as value of the
+// InitDataAnnotation pod annotation, i.e. gzip compressed and base64 encoded.
+func InitDataAnnotationValue(toml string) (string, error) {
+	compressed, err := Compress([]byte(toml), CompressionGzip)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(compressed), nil
+}
+
+// InitDataDigest returns the hex encoded digest of an initdata document that
+// the confidential VM reports as initdata measurement.
+func InitDataDigest(algorithm, toml string) (string, error) {
+	newHash, ok := initDataHashes[algorithm]
+	if !ok {
+		return "", fmt.Errorf("unsupported initdata algorithm %q, expected one of %q, %q, %q", algorithm, DigestSHA256, DigestSHA384, DigestSHA512)
+	}
+	digest := newHash()
+	digest.Write([]byte(toml))
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
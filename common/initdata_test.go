@@ -0,0 +1,133 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"testing"
+)
+
+func TestInitDataTOML(t *testing.T) {
+	toml, err := InitDataTOML(DigestSHA384, map[string]string{
+		"user-data":   "hyper-protect-basic.abc.def",
+		"policy.rego": "package agent_policy\n\ndefault AllowRequestsFailingPolicy := true\n",
+	})
+	if err != nil {
+		t.Fatalf("InitDataTOML() failed: %v", err)
+	}
+
+	expected := `algorithm = "sha384"
+version = "0.1.0"
+
+[data]
+"policy.rego" = '''
+package agent_policy
+
+default AllowRequestsFailingPolicy := true
+'''
+"user-data" = '''
+hyper-protect-basic.abc.def'''
+`
+	if toml != expected {
+		t.Errorf("Unexpected initdata:\n%s\nexpected:\n%s", toml, expected)
+	}
+
+	if _, err := InitDataTOML("md5", nil); err == nil {
+		t.Error("Expected an error for an unsupported algorithm")
+	}
+}
+
+func TestInitDataTOML_BasicString(t *testing.T) {
+	toml, err := InitDataTOML(DigestSHA256, map[string]string{"quotes": "a ''' b\r\n\"c\"\\"})
+	if err != nil {
+		t.Fatalf("InitDataTOML() failed: %v", err)
+	}
+
+	expected := `"quotes" = "a ''' b\r\n\"c\"\\"`
+	if !bytes.Contains([]byte(toml), []byte(expected)) {
+		t.Errorf("Expected initdata to contain %s, got:\n%s", expected, toml)
+	}
+}
+
+func TestTOMLString_ControlCharacters(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"tab\tand\nnewline", "'''\ntab\tand\nnewline'''"},
+		{"bell\a", `"bell\u0007"`},
+		{"escape\x1b[0m", `"escape\u001B[0m"`},
+		{"null\x00", `"null\u0000"`},
+		{"delete\x7f", `"delete\u007F"`},
+		{"carriage\rreturn", `"carriage\rreturn"`},
+		{"form\ffeed", `"form\u000Cfeed"`},
+	}
+
+	for _, tt := range tests {
+		if output := tomlString(tt.value); output != tt.expected {
+			t.Errorf("tomlString(%q): expected %s, got %s", tt.value, tt.expected, output)
+		}
+	}
+}
+
+func TestInitDataAnnotationValue(t *testing.T) {
+	toml := "algorithm = \"sha256\"\n"
+
+	value, err := InitDataAnnotationValue(toml)
+	if err != nil {
+		t.Fatalf("InitDataAnnotationValue() failed: %v", err)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("Annotation is not base64 encoded: %v", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("Annotation is not gzip compressed: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress annotation: %v", err)
+	}
+	if string(decompressed) != toml {
+		t.Errorf("Expected %q, got %q", toml, decompressed)
+	}
+}
+
+func TestInitDataDigest(t *testing.T) {
+	digest, err := InitDataDigest(DigestSHA256, "hello world")
+	if err != nil {
+		t.Fatalf("InitDataDigest() failed: %v", err)
+	}
+	if digest != "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" {
+		t.Errorf("Unexpected digest %s", digest)
+	}
+
+	digest, err = InitDataDigest(DigestSHA384, "hello world")
+	if err != nil {
+		t.Fatalf("InitDataDigest() failed: %v", err)
+	}
+	if len(digest) != 96 {
+		t.Errorf("Expected a SHA384 digest, got %s", digest)
+	}
+
+	if _, err := InitDataDigest("md5", "hello world"); err == nil {
+		t.Error("Expected an error for an unsupported algorithm")
+	}
+}
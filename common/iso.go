@@ -0,0 +1,291 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	// CloudInitVolumeID is the volume label cloud-init uses to detect a NoCloud data source.
+	CloudInitVolumeID = "cidata"
+
+	// isoSectorSize is the logical block size of the ISO image.
+	isoSectorSize = 2048
+	// isoSystemAreaSectors is the number of unused sectors at the start of the image.
+	isoSystemAreaSectors = 16
+	// isoMaxDirectorySize limits the root directory to a single sector.
+	isoMaxDirectorySize = isoSectorSize
+)
+
+// ISOFile is a file in the root directory of an ISO image.
+type ISOFile struct {
+	Name    string
+	Content []byte
+}
+
+// isoVolume describes one directory hierarchy of the image, either the plain
+// ISO 9660 one or the Joliet one with Unicode file names.
+type isoVolume struct {
+	joliet    bool
+	pathL     uint32
+	pathM     uint32
+	rootDir   uint32
+	fileNames [][]byte
+}
+
+// CreateISO builds an ISO 9660 image with Joliet extensions that holds the
+// files in its root directory. All timestamps are set to modTime so that the
+// same input always yields the same image.
+func CreateISO(volumeID string, files []ISOFile, modTime time.Time) ([]byte, error) {
+	if len(volumeID) == 0 || len(volumeID) > 16 {
+		return nil, fmt.Errorf("volume identifier must have between 1 and 16 characters")
+	}
+
+	files = append([]ISOFile(nil), files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	plain := &isoVolume{}
+	joliet := &isoVolume{joliet: true}
+	seen := make(map[string]string)
+	for _, file := range files {
+		if file.Name == "" || len(file.Name) > 64 || strings.ContainsAny(file.Name, "/\\;") {
+			return nil, fmt.Errorf("invalid file name %q", file.Name)
+		}
+		name := isoFileName(file.Name)
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("file names %q and %q map to the same ISO 9660 name %s", other, file.Name, name)
+		}
+		seen[name] = file.Name
+		plain.fileNames = append(plain.fileNames, []byte(name))
+		joliet.fileNames = append(joliet.fileNames, ucs2(file.Name))
+	}
+
+	// descriptors, then per volume the two path tables and the root directory, then the file data
+	next := uint32(isoSystemAreaSectors + 3)
+	for _, volume := range []*isoVolume{plain, joliet} {
+		volume.pathL, volume.pathM, volume.rootDir = next, next+1, next+2
+		next += 3
+	}
+	extents := make([]uint32, len(files))
+	for i, file := range files {
+		extents[i] = next
+		next += sectors(len(file.Content))
+	}
+	totalSectors := next
+
+	image := make([]byte, int(totalSectors)*isoSectorSize)
+	sector := func(index uint32) []byte {
+		return image[int(index)*isoSectorSize : int(index+1)*isoSectorSize]
+	}
+
+	for i, volume := range []*isoVolume{plain, joliet} {
+		directory, err := volume.directory(files, extents, modTime)
+		if err != nil {
+			return nil, err
+		}
+		copy(sector(volume.rootDir), directory)
+
+		pathL, pathM := volume.pathTables()
+		copy(sector(volume.pathL), pathL)
+		copy(sector(volume.pathM), pathM)
+
+		volume.descriptor(sector(isoSystemAreaSectors+uint32(i)), volumeID, totalSectors, uint32(len(pathL)), modTime)
+	}
+
+	terminator := sector(isoSystemAreaSectors + 2)
+	terminator[0] = 255
+	copy(terminator[1:6], "CD001")
+	terminator[6] = 1
+
+	for i, file := range files {
+		copy(image[int(extents[i])*isoSectorSize:], file.Content)
+	}
+
+	return image, nil
+}
+
+// isoFileName maps a file name to an ISO 9660 level 1 name, e.g. "user-data"
+// becomes "USER_DAT.;1".
+func isoFileName(name string) string {
+	base, extension := name, ""
+	if index := strings.LastIndex(name, "."); index > 0 {
+		base, extension = name[:index], name[index+1:]
+	}
+	base, extension = isoDChars(base, 8), isoDChars(extension, 3)
+	return base + "." + extension + ";1"
+}
+
+// isoDChars converts a string to at most limit ISO 9660 d-characters.
+func isoDChars(value string, limit int) string {
+	var result strings.Builder
+	for _, char := range strings.ToUpper(value) {
+		if result.Len() == limit {
+			break
+		}
+		if (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9') || char == '_' {
+			result.WriteRune(char)
+		} else {
+			result.WriteByte('_')
+		}
+	}
+	return result.String()
+}
+
+// ucs2 encodes a string as big endian UCS-2, as required by Joliet.
+func ucs2(value string) []byte {
+	encoded := utf16.Encode([]rune(value))
+	result := make([]byte, 2*len(encoded))
+	for i, char := range encoded {
+		binary.BigEndian.PutUint16(result[2*i:], char)
+	}
+	return result
+}
+
+// sectors returns the number of sectors needed for size bytes.
+func sectors(size int) uint32 {
+	return uint32((size + isoSectorSize - 1) / isoSectorSize)
+}
+
+// directory builds the root directory of the volume.
+func (v *isoVolume) directory(files []ISOFile, extents []uint32, modTime time.Time) ([]byte, error) {
+	var directory []byte
+	directory = append(directory, directoryRecord([]byte{0}, v.rootDir, isoSectorSize, true, modTime)...)
+	directory = append(directory, directoryRecord([]byte{1}, v.rootDir, isoSectorSize, true, modTime)...)
+	for i, file := range files {
+		directory = append(directory, directoryRecord(v.fileNames[i], extents[i], uint32(len(file.Content)), false, modTime)...)
+	}
+	if len(directory) > isoMaxDirectorySize {
+		return nil, fmt.Errorf("too many files for the root directory")
+	}
+	return directory, nil
+}
+
+// pathTables builds the little and big endian path tables of the volume,
+// which only contain the root directory.
+func (v *isoVolume) pathTables() ([]byte, []byte) {
+	pathL := []byte{1, 0, 0, 0, 0, 0, 1, 0, 0, 0}
+	binary.LittleEndian.PutUint32(pathL[2:], v.rootDir)
+	pathM := []byte{1, 0, 0, 0, 0, 0, 0, 1, 0, 0}
+	binary.BigEndian.PutUint32(pathM[2:], v.rootDir)
+	return pathL, pathM
+}
+
+// descriptor writes the primary or, for Joliet, the supplementary volume descriptor.
+func (v *isoVolume) descriptor(buffer []byte, volumeID string, totalSectors, pathTableSize uint32, modTime time.Time) {
+	text := func(offset, length int, value string) {
+		if v.joliet {
+			fillUCS2(buffer[offset:offset+length], value)
+		} else {
+			fillASCII(buffer[offset:offset+length], value)
+		}
+	}
+
+	buffer[0] = 1
+	if v.joliet {
+		buffer[0] = 2
+	}
+	copy(buffer[1:6], "CD001")
+	buffer[6] = 1
+	text(8, 32, "")
+	if v.joliet {
+		text(40, 32, volumeID)
+		// escape sequence for UCS-2 level 3
+		copy(buffer[88:91], "%/E")
+	} else {
+		text(40, 32, strings.ToUpper(volumeID))
+	}
+	putBothEndian32(buffer[80:], totalSectors)
+	putBothEndian16(buffer[120:], 1)
+	putBothEndian16(buffer[124:], 1)
+	putBothEndian16(buffer[128:], isoSectorSize)
+	putBothEndian32(buffer[132:], pathTableSize)
+	binary.LittleEndian.PutUint32(buffer[140:], v.pathL)
+	binary.BigEndian.PutUint32(buffer[148:], v.pathM)
+	copy(buffer[156:190], directoryRecord([]byte{0}, v.rootDir, isoSectorSize, true, modTime))
+	text(190, 128, "")
+	text(318, 128, "")
+	text(446, 128, "")
+	text(574, 128, "")
+	text(702, 37, "")
+	text(739, 37, "")
+	text(776, 37, "")
+
+	timestamp := modTime.UTC().Format("20060102150405") + "00"
+	copy(buffer[813:], timestamp)
+	copy(buffer[830:], timestamp)
+	copy(buffer[847:], "0000000000000000")
+	copy(buffer[864:], timestamp)
+	buffer[881] = 1
+}
+
+// directoryRecord builds a directory record for a file or directory.
+func directoryRecord(identifier []byte, extent, size uint32, isDirectory bool, modTime time.Time) []byte {
+	length := 33 + len(identifier)
+	if length%2 == 1 {
+		length++
+	}
+
+	record := make([]byte, length)
+	record[0] = byte(length)
+	putBothEndian32(record[2:], extent)
+	putBothEndian32(record[10:], size)
+
+	utc := modTime.UTC()
+	record[18] = byte(utc.Year() - 1900)
+	record[19] = byte(utc.Month())
+	record[20] = byte(utc.Day())
+	record[21] = byte(utc.Hour())
+	record[22] = byte(utc.Minute())
+	record[23] = byte(utc.Second())
+
+	if isDirectory {
+		record[25] = 2
+	}
+	putBothEndian16(record[28:], 1)
+	record[32] = byte(len(identifier))
+	copy(record[33:], identifier)
+
+	return record
+}
+
+func fillASCII(buffer []byte, value string) {
+	for i := range buffer {
+		buffer[i] = ' '
+	}
+	copy(buffer, value)
+}
+
+func fillUCS2(buffer []byte, value string) {
+	for i := 0; i+1 < len(buffer); i += 2 {
+		buffer[i], buffer[i+1] = 0, ' '
+	}
+	copy(buffer, ucs2(value))
+}
+
+func putBothEndian16(buffer []byte, value uint16) {
+	binary.LittleEndian.PutUint16(buffer[0:], value)
+	binary.BigEndian.PutUint16(buffer[2:], value)
+}
+
+func putBothEndian32(buffer []byte, value uint32) {
+	binary.LittleEndian.PutUint32(buffer[0:], value)
+	binary.BigEndian.PutUint32(buffer[4:], value)
+}
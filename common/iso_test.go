@@ -0,0 +1,111 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func testISOFiles() []ISOFile {
+	return []ISOFile{
+		{Name: "user-data", Content: []byte("hyper-protect-basic.abc.def")},
+		{Name: "meta-data", Content: []byte("local-hostname: hpcr\n")},
+		{Name: "vendor-data", Content: []byte{}},
+	}
+}
+
+func TestCreateISO(t *testing.T) {
+	image, err := CreateISO(CloudInitVolumeID, testISOFiles(), time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("CreateISO() failed: %v", err)
+	}
+
+	if len(image)%isoSectorSize != 0 {
+		t.Errorf("Expected image size to be a multiple of %d, got %d", isoSectorSize, len(image))
+	}
+
+	primary := image[16*isoSectorSize:]
+	if primary[0] != 1 || string(primary[1:6]) != "CD001" {
+		t.Error("Expected a primary volume descriptor in sector 16")
+	}
+	if string(primary[40:46]) != "CIDATA" {
+		t.Errorf("Expected primary volume identifier CIDATA, got %q", primary[40:72])
+	}
+
+	joliet := image[17*isoSectorSize:]
+	if joliet[0] != 2 || string(joliet[88:91]) != "%/E" {
+		t.Error("Expected a Joliet supplementary volume descriptor in sector 17")
+	}
+	if !bytes.Equal(joliet[40:52], ucs2("cidata")) {
+		t.Errorf("Expected Joliet volume identifier cidata, got %q", joliet[40:72])
+	}
+
+	terminator := image[18*isoSectorSize:]
+	if terminator[0] != 255 {
+		t.Error("Expected a volume descriptor set terminator in sector 18")
+	}
+
+	if !bytes.Contains(image, ucs2("vendor-data")) || !bytes.Contains(image, []byte("USER_DAT.;1")) {
+		t.Error("Expected the image to contain the Joliet and ISO 9660 file names")
+	}
+	if !bytes.Contains(image, []byte("hyper-protect-basic.abc.def")) {
+		t.Error("Expected the image to contain the file content")
+	}
+}
+
+func TestCreateISO_Deterministic(t *testing.T) {
+	first, err := CreateISO(CloudInitVolumeID, testISOFiles(), time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("CreateISO() failed: %v", err)
+	}
+
+	files := testISOFiles()
+	files[0], files[2] = files[2], files[0]
+	second, err := CreateISO(CloudInitVolumeID, files, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("CreateISO() failed: %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Error("Expected the same files to produce the same image")
+	}
+}
+
+func TestCreateISO_InvalidInput(t *testing.T) {
+	if _, err := CreateISO("", testISOFiles(), time.Unix(0, 0)); err == nil {
+		t.Error("Expected an error for an empty volume identifier")
+	}
+	if _, err := CreateISO(CloudInitVolumeID, []ISOFile{{Name: "dir/user-data"}}, time.Unix(0, 0)); err == nil {
+		t.Error("Expected an error for a file name with a path")
+	}
+	if _, err := CreateISO(CloudInitVolumeID, []ISOFile{{Name: "meta-data"}, {Name: "meta_data"}}, time.Unix(0, 0)); err == nil {
+		t.Error("Expected an error for file names that map to the same ISO 9660 name")
+	}
+}
+
+func TestISOFileName(t *testing.T) {
+	tests := map[string]string{
+		"user-data":      "USER_DAT.;1",
+		"network-config": "NETWORK_.;1",
+		"contract.yaml":  "CONTRACT.YAM;1",
+	}
+	for name, expected := range tests {
+		if got := isoFileName(name); got != expected {
+			t.Errorf("isoFileName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
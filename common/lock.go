@@ -0,0 +1,134 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// LockFileVersion is the format version written to lock files.
+const LockFileVersion = 1
+
+// lockFileMutex serializes updates of lock files by data sources that are read concurrently.
+var lockFileMutex sync.Mutex
+
+// LockEntry records the resolution of a version range.
+type LockEntry struct {
+	Generation      int64  `json:"generation"`
+	Spec            string `json:"spec"`
+	Version         string `json:"version"`
+	Image           string `json:"image,omitempty"`
+	Sha256          string `json:"sha256,omitempty"`
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
+}
+
+// LockFile pins the versions selected by data sources, keyed by lock key.
+type LockFile struct {
+	Version int                  `json:"version"`
+	Entries map[string]LockEntry `json:"entries"`
+}
+
+// ReadLockFile reads a lock file. A missing file yields an empty lock file.
+func ReadLockFile(path string) (*LockFile, error) {
+	lock := &LockFile{Version: LockFileVersion, Entries: map[string]LockEntry{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file %s: %v", path, err)
+	}
+
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %v", path, err)
+	}
+	if lock.Version != LockFileVersion {
+		return nil, fmt.Errorf("unsupported lock file version %d in %s, expected %d", lock.Version, path, LockFileVersion)
+	}
+	if lock.Entries == nil {
+		lock.Entries = map[string]LockEntry{}
+	}
+
+	return lock, nil
+}
+
+// Lookup returns the entry for key if it was recorded for the same generation and spec.
+func (l *LockFile) Lookup(key string, generation int64, spec string) (LockEntry, bool) {
+	entry, ok := l.Entries[key]
+	if !ok || entry.Generation != generation || entry.Spec != spec {
+		return LockEntry{}, false
+	}
+	return entry, true
+}
+
+// Verify checks that the recorded checksum and certificate fingerprint are
+// unchanged. Empty values are not compared.
+func (e LockEntry) Verify(sha256, certFingerprint string) error {
+	if e.Sha256 != "" && sha256 != "" && e.Sha256 != sha256 {
+		return fmt.Errorf("sha256 of version %s changed from %s to %s", e.Version, e.Sha256, sha256)
+	}
+	if e.CertFingerprint != "" && certFingerprint != "" && e.CertFingerprint != certFingerprint {
+		return fmt.Errorf("certificate fingerprint of version %s changed from %s to %s", e.Version, e.CertFingerprint, certFingerprint)
+	}
+	return nil
+}
+
+// RecordLock stores the entry under key in the lock file at path, preserving all other entries.
+func RecordLock(path, key string, entry LockEntry) error {
+	lockFileMutex.Lock()
+	defer lockFileMutex.Unlock()
+
+	lock, err := ReadLockFile(path)
+	if err != nil {
+		return err
+	}
+	lock.Entries[key] = entry
+
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize lock file: %v", err)
+	}
+	data = append(data, '\n')
+
+	// write to a temporary file first so that readers never see a partial lock file
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write lock file %s: %v", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write lock file %s: %v", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write lock file %s: %v", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write lock file %s: %v", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write lock file %s: %v", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadLockFile_Missing(t *testing.T) {
+	lock, err := ReadLockFile(filepath.Join(t.TempDir(), "hpcr.lock.json"))
+	if err != nil {
+		t.Fatalf("ReadLockFile() failed: %v", err)
+	}
+	if lock.Version != LockFileVersion || len(lock.Entries) != 0 {
+		t.Errorf("Expected an empty lock file, got %+v", lock)
+	}
+}
+
+func TestReadLockFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hpcr.lock.json")
+
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadLockFile(path); err == nil {
+		t.Error("Expected an error for an invalid lock file")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadLockFile(path); err == nil {
+		t.Error("Expected an error for an unsupported lock file version")
+	}
+}
+
+func TestRecordLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hpcr.lock.json")
+
+	image := LockEntry{Spec: ">=1.0.0", Version: "1.0.20", Image: "r006-1", Sha256: "aaa"}
+	cert := LockEntry{Spec: ">=1.0.0", Version: "1.0.20", CertFingerprint: "fff"}
+	if err := RecordLock(path, "image", image); err != nil {
+		t.Fatalf("RecordLock() failed: %v", err)
+	}
+	if err := RecordLock(path, "cert", cert); err != nil {
+		t.Fatalf("RecordLock() failed: %v", err)
+	}
+
+	lock, err := ReadLockFile(path)
+	if err != nil {
+		t.Fatalf("ReadLockFile() failed: %v", err)
+	}
+	if got, ok := lock.Lookup("image", 0, ">=1.0.0"); !ok || got != image {
+		t.Errorf("Expected image entry %+v, got %+v", image, got)
+	}
+	if got, ok := lock.Lookup("cert", 0, ">=1.0.0"); !ok || got != cert {
+		t.Errorf("Expected cert entry %+v, got %+v", cert, got)
+	}
+}
+
+func TestLockFile_Lookup(t *testing.T) {
+	lock := &LockFile{Version: LockFileVersion, Entries: map[string]LockEntry{
+		"image": {Generation: 1, Spec: "*", Version: "1.0.20"},
+	}}
+
+	if _, ok := lock.Lookup("image", 1, "*"); !ok {
+		t.Error("Expected entry to be found")
+	}
+	if _, ok := lock.Lookup("image", 2, "*"); ok {
+		t.Error("Expected a new generation to ignore the entry")
+	}
+	if _, ok := lock.Lookup("image", 1, ">=1.1.0"); ok {
+		t.Error("Expected a changed spec to ignore the entry")
+	}
+	if _, ok := lock.Lookup("other", 1, "*"); ok {
+		t.Error("Expected an unknown key not to be found")
+	}
+}
+
+func TestLockEntry_Verify(t *testing.T) {
+	entry := LockEntry{Version: "1.0.20", Sha256: "aaa", CertFingerprint: "fff"}
+
+	if err := entry.Verify("aaa", "fff"); err != nil {
+		t.Errorf("Expected unchanged values to verify: %v", err)
+	}
+	if err := entry.Verify("aaa", ""); err != nil {
+		t.Errorf("Expected empty values not to be compared: %v", err)
+	}
+	if err := entry.Verify("bbb", "fff"); err == nil {
+		t.Error("Expected an error for a changed sha256")
+	}
+	if err := entry.Verify("aaa", "ggg"); err == nil {
+		t.Error("Expected an error for a changed certificate fingerprint")
+	}
+}
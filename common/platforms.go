@@ -0,0 +1,114 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// DefaultPlatform is the Hyper Protect platform assumed when none is configured.
+const DefaultPlatform = "hpvs"
+
+// Platform describes a Hyper Protect platform supported by the provider.
+type Platform struct {
+	// Name is the value of the platform attribute.
+	Name string
+	// Description names the product.
+	Description string
+}
+
+// platforms lists the supported platforms. There is no published matrix of the
+// runtime versions of each platform, so versions are not restricted per
+// platform.
+var platforms = []Platform{
+	{
+		Name:        "hpvs",
+		Description: "IBM Hyper Protect Virtual Servers",
+	},
+	{
+		Name:        "ccrt",
+		Description: "IBM Confidential Computing Container Runtime",
+	},
+	{
+		Name:        "ccrv",
+		Description: "IBM Confidential Computing Container Runtime for Red Hat Virtualization Solutions",
+	},
+	{
+		Name:        "hpcr-rhvs",
+		Description: "IBM Hyper Protect Container Runtime for Red Hat Virtualization Solutions",
+	},
+	{
+		Name:        "hpcc-peerpod",
+		Description: "IBM Hyper Protect Confidential Container peer pods",
+	},
+}
+
+// Platforms returns the matrix of supported platforms.
+func Platforms() []Platform {
+	return append([]Platform(nil), platforms...)
+}
+
+// PlatformNames returns the names of the supported platforms.
+func PlatformNames() []string {
+	names := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		names = append(names, platform.Name)
+	}
+	return names
+}
+
+// LookupPlatform returns the platform with the given name. An empty name
+// refers to DefaultPlatform.
+func LookupPlatform(name string) (Platform, error) {
+	if name == "" {
+		name = DefaultPlatform
+	}
+	for _, platform := range platforms {
+		if platform.Name == name {
+			return platform, nil
+		}
+	}
+	return Platform{}, fmt.Errorf("unsupported platform %q, expected one of %s", name, quoteAll(PlatformNames()))
+}
+
+// ValidatePlatformVersion checks that the platform is supported and that
+// version is a version number such as 1.0.23, 25.11 or 25.11.0. An empty
+// version refers to the latest runtime and is always accepted. Whether the
+// release exists for the platform is not checked.
+func ValidatePlatformVersion(name, version string) error {
+	if _, err := LookupPlatform(name); err != nil {
+		return err
+	}
+	if version == "" {
+		return nil
+	}
+
+	if _, err := semver.NewVersion(version); err != nil {
+		return fmt.Errorf("invalid version %q, expected a version such as 1.0.23 or 25.11.0", version)
+	}
+
+	return nil
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+)
+
+func TestPlatforms(t *testing.T) {
+	for _, platform := range Platforms() {
+		if platform.Name == "" || platform.Description == "" {
+			t.Errorf("Expected platform %+v to have a name and description", platform)
+		}
+	}
+}
+
+func TestLookupPlatform(t *testing.T) {
+	platform, err := LookupPlatform("")
+	if err != nil {
+		t.Fatalf("LookupPlatform() failed: %v", err)
+	}
+	if platform.Name != DefaultPlatform {
+		t.Errorf("Expected the default platform, got %s", platform.Name)
+	}
+
+	if _, err := LookupPlatform("hpcc-peerpod"); err != nil {
+		t.Errorf("Expected hpcc-peerpod to be supported: %v", err)
+	}
+	for _, invalid := range []string{"hpvs ", "HPVS", "unknown"} {
+		if _, err := LookupPlatform(invalid); err == nil {
+			t.Errorf("Expected an error for platform %q", invalid)
+		}
+	}
+}
+
+func TestValidatePlatformVersion(t *testing.T) {
+	valid := [][2]string{
+		{"hpvs", ""},
+		{"hpvs", "1.0.23"},
+		{"", "25.11.0"},
+		{"hpcr-rhvs", "25.11.0"},
+		{"hpvs", "25.11"},
+		{"hpvs", "0.9.0"},
+	}
+	for _, test := range valid {
+		if err := ValidatePlatformVersion(test[0], test[1]); err != nil {
+			t.Errorf("Expected platform %q version %q to be valid: %v", test[0], test[1], err)
+		}
+	}
+
+	invalid := [][2]string{
+		{"hpvs", "latest"},
+		{"hpvs", "1.0.x"},
+		{"unknown", "1.0.0"},
+	}
+	for _, test := range invalid {
+		if err := ValidatePlatformVersion(test[0], test[1]); err == nil {
+			t.Errorf("Expected platform %q version %q to be rejected", test[0], test[1])
+		}
+	}
+}
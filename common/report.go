@@ -0,0 +1,180 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// ReportFormatJSON renders the attestation report as a plain JSON document.
+	ReportFormatJSON = "json"
+	// ReportFormatInToto renders the attestation report as an in-toto statement.
+	ReportFormatInToto = "in-toto"
+
+	// inTotoStatementType is the type of an in-toto v1 statement.
+	inTotoStatementType = "https://in-toto.io/Statement/v1"
+	// ReportSignatureVerified marks a report whose attestation signature was verified.
+	ReportSignatureVerified = "verified"
+	// ReportSignatureNotVerified marks a report whose attestation signature was not checked.
+	ReportSignatureNotVerified = "not_verified"
+
+	// AttestationPredicateType identifies the predicate of attestation reports in in-toto statements.
+	AttestationPredicateType = "https://github.com/ibm-hyper-protect/terraform-provider-hpcr/attestation-report/v1"
+)
+
+// AttestationReport is the audit evidence produced after an attestation
+// record has been decrypted, verified and parsed.
+type AttestationReport struct {
+	// GeneratedAt is the time the report was generated in RFC 3339 format.
+	GeneratedAt  string                   `json:"generated_at"`
+	Inputs       AttestationReportInputs  `json:"inputs"`
+	Certificate  *AttestationReportSigner `json:"certificate,omitempty"`
+	Signature    string                   `json:"signature"`
+	Algorithm    string                   `json:"algorithm"`
+	Metadata     map[string]string        `json:"metadata"`
+	Records      []AttestationRecord      `json:"records,omitempty"`
+	Verification *AttestationReportResult `json:"verification,omitempty"`
+}
+
+// AttestationReportInputs describes how the attestation record was processed.
+type AttestationReportInputs struct {
+	Encrypted      bool              `json:"encrypted"`
+	SignatureInput bool              `json:"signature_provided"`
+	TrustBundle    bool              `json:"trust_bundle_provided"`
+	CRLs           int               `json:"crls"`
+	MinAlgorithm   string            `json:"min_algorithm,omitempty"`
+	Expected       map[string]string `json:"expected,omitempty"`
+}
+
+// AttestationReportSigner describes the attestation certificate.
+type AttestationReportSigner struct {
+	Subject           string `json:"subject"`
+	ValidUntil        string `json:"valid_until"`
+	FingerprintSha256 string `json:"fingerprint_sha256"`
+	ChainValidated    bool   `json:"chain_validated"`
+}
+
+// AttestationReportResult is the outcome of the comparison with the expected checksums.
+type AttestationReportResult struct {
+	Verified   bool     `json:"verified"`
+	Mismatches []string `json:"mismatches"`
+}
+
+// NewAttestationReportSigner describes an attestation certificate for a report.
+func NewAttestationReportSigner(cert *x509.Certificate, chainValidated bool) *AttestationReportSigner {
+	return &AttestationReportSigner{
+		Subject:           cert.Subject.String(),
+		ValidUntil:        cert.NotAfter.UTC().Format(time.RFC3339),
+		FingerprintSha256: CertificateFingerprint(cert),
+		ChainValidated:    chainValidated,
+	}
+}
+
+// CertificateFingerprint returns the hex encoded SHA256 fingerprint of a certificate.
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// Render serializes the report in the given format, either ReportFormatJSON
+// or ReportFormatInToto. An empty format is treated as ReportFormatJSON.
+func (r *AttestationReport) Render(format string) (string, error) {
+	var document interface{}
+	switch format {
+	case "", ReportFormatJSON:
+		document = r
+	case ReportFormatInToto:
+		document = r.inTotoStatement()
+	default:
+		return "", fmt.Errorf("unsupported report format %q, expected one of %q, %q", format, ReportFormatJSON, ReportFormatInToto)
+	}
+
+	reportBytes, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal attestation report: %v", err)
+	}
+
+	return string(reportBytes), nil
+}
+
+// PreviousGeneratedAt returns the generation time of previous, a report
+// rendered earlier in the given format, if it only differs from this report in
+// its generation time. This keeps the timestamp of a report that is written
+// again for the same inputs.
+func (r *AttestationReport) PreviousGeneratedAt(previous, format string) (string, bool) {
+	var times struct {
+		GeneratedAt string `json:"generated_at"`
+		Predicate   struct {
+			GeneratedAt string `json:"generated_at"`
+		} `json:"predicate"`
+	}
+	if err := json.Unmarshal([]byte(previous), &times); err != nil {
+		return "", false
+	}
+	generatedAt := times.GeneratedAt
+	if format == ReportFormatInToto {
+		generatedAt = times.Predicate.GeneratedAt
+	}
+	if generatedAt == "" {
+		return "", false
+	}
+
+	candidate := *r
+	candidate.GeneratedAt = generatedAt
+	rendered, err := candidate.Render(format)
+	if err != nil || rendered != previous {
+		return "", false
+	}
+
+	return generatedAt, true
+}
+
+// inTotoStatement wraps the report in an in-toto v1 statement. The measured
+// files are the subjects, the remaining report is the predicate.
+func (r *AttestationReport) inTotoStatement() interface{} {
+	type subject struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	}
+
+	subjects := make([]subject, 0, len(r.Records))
+	for _, record := range r.Records {
+		subjects = append(subjects, subject{
+			Name:   record.Filename,
+			Digest: map[string]string{record.Algorithm: record.Checksum},
+		})
+	}
+
+	predicate := *r
+	predicate.Records = nil
+
+	return struct {
+		Type          string            `json:"_type"`
+		Subject       []subject         `json:"subject"`
+		PredicateType string            `json:"predicateType"`
+		Predicate     AttestationReport `json:"predicate"`
+	}{
+		Type:          inTotoStatementType,
+		Subject:       subjects,
+		PredicateType: AttestationPredicateType,
+		Predicate:     predicate,
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func testReport() *AttestationReport {
+	return &AttestationReport{
+		GeneratedAt: "2026-01-02T03:04:05Z",
+		Inputs:      AttestationReportInputs{Encrypted: true},
+		Signature:   ReportSignatureNotVerified,
+		Algorithm:   DigestSHA256,
+		Metadata:    map[string]string{ImageVersionKey: "25.11.0"},
+		Records: []AttestationRecord{
+			{Filename: "contract:env", Checksum: "268d6c045b2d8a5e1a67d9e88ff101a04af1bc11cdd35067a80291fb8dca8f84", Algorithm: DigestSHA256},
+		},
+	}
+}
+
+func TestAttestationReport_RenderJSON(t *testing.T) {
+	output, err := testReport().Render(ReportFormatJSON)
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"generated_at", "inputs", "signature", "algorithm", "metadata", "records"} {
+		if _, ok := parsed[key]; !ok {
+			t.Errorf("Expected report to contain '%s'", key)
+		}
+	}
+	records := parsed["records"].([]interface{})
+	if records[0].(map[string]interface{})["filename"] != "contract:env" {
+		t.Errorf("Unexpected records: %v", records)
+	}
+}
+
+func TestAttestationReport_RenderInToto(t *testing.T) {
+	output, err := testReport().Render(ReportFormatInToto)
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+
+	var statement struct {
+		Type    string `json:"_type"`
+		Subject []struct {
+			Name   string            `json:"name"`
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+		PredicateType string                 `json:"predicateType"`
+		Predicate     map[string]interface{} `json:"predicate"`
+	}
+	if err := json.Unmarshal([]byte(output), &statement); err != nil {
+		t.Fatalf("Statement is not valid JSON: %v", err)
+	}
+
+	if statement.Type != "https://in-toto.io/Statement/v1" {
+		t.Errorf("Unexpected statement type %s", statement.Type)
+	}
+	if len(statement.Subject) != 1 || statement.Subject[0].Digest[DigestSHA256] == "" {
+		t.Errorf("Unexpected subjects: %v", statement.Subject)
+	}
+	if statement.PredicateType != AttestationPredicateType {
+		t.Errorf("Unexpected predicate type %s", statement.PredicateType)
+	}
+	if _, ok := statement.Predicate["records"]; ok {
+		t.Error("Expected records to be moved to the statement subjects")
+	}
+}
+
+func TestAttestationReport_RenderUnsupported(t *testing.T) {
+	if _, err := testReport().Render("xml"); err == nil {
+		t.Error("Render() should fail for an unsupported format")
+	}
+}
+
+func TestAttestationReport_PreviousGeneratedAt(t *testing.T) {
+	for _, format := range []string{ReportFormatJSON, ReportFormatInToto} {
+		t.Run(format, func(t *testing.T) {
+			previous := testReport()
+			previous.GeneratedAt = "2026-01-02T03:04:05Z"
+			rendered, err := previous.Render(format)
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+
+			report := testReport()
+			report.GeneratedAt = "2026-02-01T00:00:00Z"
+			generatedAt, ok := report.PreviousGeneratedAt(rendered, format)
+			if !ok || generatedAt != previous.GeneratedAt {
+				t.Errorf("Expected the previous generation time to be kept, got %q", generatedAt)
+			}
+
+			report.Signature = ReportSignatureVerified
+			if _, ok := report.PreviousGeneratedAt(rendered, format); ok {
+				t.Error("Expected a changed report to get a new generation time")
+			}
+			if _, ok := report.PreviousGeneratedAt("not json", format); ok {
+				t.Error("Expected an unreadable previous report to be ignored")
+			}
+		})
+	}
+}
@@ -39,3 +39,25 @@ output "attestation_unencrypted" {
 output "attestation_verified" {
   value = data.hpcr_attestation.attestation_verified.checksums
 }
+
+# Compare the attestation record with expected checksums
+data "hpcr_attestation" "attestation_expected" {
+  attestation = file("./cert/se-checksums.txt")
+
+  expected = {
+    "cidata/user-data" = var.user_data_sha256
+  }
+}
+
+variable "user_data_sha256" {
+  type        = string
+  description = "Expected SHA256 of the user data, e.g. the sha256_out of hpcr_contract_encrypted"
+}
+
+output "attestation_expected_verified" {
+  value = data.hpcr_attestation.attestation_expected.verified
+}
+
+output "attestation_expected_mismatches" {
+  value = data.hpcr_attestation.attestation_expected.mismatches
+}
@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+# Compare the attestation records of two deployments
+data "hpcr_attestation_diff" "redeploy" {
+  old_attestation = file("./records/se-checksums-old.txt")
+  new_attestation = file("./records/se-checksums-new.txt")
+}
+
+# Encrypted attestation records are decrypted with their private keys
+# data "hpcr_attestation_diff" "redeploy_encrypted" {
+#   old_attestation = file("./records/se-checksums-old.txt.enc")
+#   old_privkey     = file("./cert/private-old.pem")
+#   new_attestation = file("./records/se-checksums-new.txt.enc")
+#   new_privkey     = file("./cert/private-new.pem")
+# }
+
+output "attestation_added" {
+  value = data.hpcr_attestation_diff.redeploy.added
+}
+
+output "attestation_removed" {
+  value = data.hpcr_attestation_diff.redeploy.removed
+}
+
+output "attestation_changed" {
+  value = data.hpcr_attestation_diff.redeploy.changed
+}
@@ -19,13 +19,18 @@ provider "ibm" {
 
 data "ibm_is_images" "ibm_images" {
   visibility = "public"
-  status     = "available"
 }
 
 data "hpcr_image" "hyper_protect_image" {
   images = jsonencode(data.ibm_is_images.ibm_images.images)
 }
 
+data "hpcr_image" "hyper_protect_image_stable" {
+  images            = jsonencode(data.ibm_is_images.ibm_images.images)
+  architecture      = "s390x"
+  deprecation_grace = 14
+}
+
 output "hpcr_image_id" {
   value = data.hpcr_image.hyper_protect_image.id
 }
@@ -0,0 +1,60 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+
+    ibm = {
+      source  = "IBM-Cloud/ibm"
+      version = ">= 1.37.1"
+    }
+  }
+}
+
+provider "ibm" {
+  region = "us-south"
+  zone   = "us-south-3"
+}
+
+data "ibm_is_images" "ibm_images" {
+  visibility = "public"
+  status     = "available"
+}
+
+# Select the image and download the encryption certificate of the same version
+data "hpcr_image_bundle" "bundle" {
+  images = jsonencode(data.ibm_is_images.ibm_images.images)
+  spec   = ">=1.1.0"
+}
+
+# Encrypt the contract for exactly the selected image version
+resource "hpcr_contract_encrypted" "contract" {
+  contract = yamlencode({
+    "env" : {
+      "type" : "env",
+      "logging" : {
+        "logRouter" : {
+          "hostname" : "5c2d6b69-c7f0-41bd-b69b-240695369d6e.ingress.us-south.logs.cloud.ibm.com",
+          "iamApiKey" : "ab00e3c09p1d4ff7fff9f04c12183413"
+        }
+      }
+    },
+    "workload" : {
+      "type" : "workload"
+    }
+  })
+  cert     = data.hpcr_image_bundle.bundle.cert
+}
+
+output "hpcr_image_id" {
+  value = data.hpcr_image_bundle.bundle.image_id
+}
+
+output "hpcr_image_version" {
+  value = data.hpcr_image_bundle.bundle.version
+}
+
+output "hpcr_cert_status" {
+  value = data.hpcr_image_bundle.bundle.cert_status
+}
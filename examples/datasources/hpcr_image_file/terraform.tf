@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+# Verify a downloaded HPCR qcow2 image for an on-prem KVM deployment
+data "hpcr_image_file" "hpcr_qcow2" {
+  path               = "${path.module}/images/ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2"
+  checksum_file      = file("${path.module}/images/ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2.sha256")
+  checksum_signature = filebase64("${path.module}/images/ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2.sha256.sig")
+  cert               = file("${path.module}/cert/ibm-hyper-protect-image-signing.crt")
+  trust_bundle       = file("${path.module}/cert/ibm-root-ca.crt")
+}
+
+output "hpcr_image_file_version" {
+  value = data.hpcr_image_file.hpcr_qcow2.version
+}
+
+output "hpcr_image_file_sha256" {
+  value = data.hpcr_image_file.hpcr_qcow2.sha256
+}
+
+output "hpcr_image_file_verified" {
+  value = data.hpcr_image_file.hpcr_qcow2.verified
+}
@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+
+    ibm = {
+      source  = "IBM-Cloud/ibm"
+      version = ">= 1.37.1"
+    }
+  }
+}
+
+provider "ibm" {
+  region = "us-south"
+  zone   = "us-south-3"
+}
+
+data "ibm_is_images" "ibm_images" {
+  visibility = "public"
+}
+
+# The two newest available HPCR images, e.g. for a canary rollout
+data "hpcr_images" "canary" {
+  images = jsonencode(data.ibm_is_images.ibm_images.images)
+  spec   = ">=1.1.0"
+  status = "available"
+  limit  = 2
+}
+
+output "hpcr_images" {
+  value = data.hpcr_images.canary.matches
+}
+
+output "hpcr_images_latest" {
+  value = data.hpcr_images.canary.matches[0].id
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+data "hpcr_platforms" "supported" {
+}
+
+output "hpcr_platforms" {
+  value = { for platform in data.hpcr_platforms.supported.platforms : platform.name => platform.description }
+}
+
+output "hpcr_default_platform" {
+  value = data.hpcr_platforms.supported.default_platform
+}
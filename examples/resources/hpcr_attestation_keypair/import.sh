@@ -0,0 +1,5 @@
+# Import an existing private key, e.g. to keep decrypting attestation records after a state loss
+terraform import hpcr_attestation_keypair.example "attestation_keypair:file:private.pem"
+
+# Import the private key from an environment variable, e.g. a CI secret
+terraform import hpcr_attestation_keypair.example "attestation_keypair:env:ATTESTATION_PRIVATE_KEY"
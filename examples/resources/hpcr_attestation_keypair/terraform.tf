@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+# Generate the key pair for encrypted attestation records
+resource "hpcr_attestation_keypair" "attestation" {}
+
+# Create TGZ archive from compose folder
+resource "hpcr_tgz" "contract" {
+  folder = "compose"
+}
+
+# Use the public key as attestationPublicKey in the contract
+resource "hpcr_contract_encrypted" "contract" {
+  contract = yamlencode({
+    "env" : {
+      "type" : "env",
+      "logging" : {
+        "logRouter" : {
+          "hostname" : "5c2d6b69-c7f0-41bd-b69b-240695369d6e.ingress.us-south.logs.cloud.ibm.com",
+          "iamApiKey" : "ab00e3c09p1d4ff7fff9f04c12183413"
+        }
+      }
+    },
+    "workload" : {
+      "type" : "workload",
+      "compose" : {
+        "archive" : hpcr_tgz.contract.rendered
+      }
+    },
+    "attestationPublicKey" : hpcr_attestation_keypair.attestation.public_key_base64
+  })
+}
+
+# Decrypt the attestation record with the private key once the instance is running
+data "hpcr_attestation" "attestation" {
+  attestation = file("./se-checksums.txt.enc")
+  privkey     = hpcr_attestation_keypair.attestation.private_key
+}
+
+output "attestation_public_key" {
+  value = hpcr_attestation_keypair.attestation.public_key
+}
+
+output "attestation_checksums" {
+  value = data.hpcr_attestation.attestation.checksums
+}
@@ -0,0 +1 @@
+terraform import hpcr_cloudinit_iso.example "cloudinit_iso:./build/cidata.iso"
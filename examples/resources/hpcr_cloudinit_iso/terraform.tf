@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+resource "hpcr_contract_encrypted" "contract" {
+  contract = yamlencode({
+    "env" : {
+      "type" : "env",
+      "logging" : {
+        "logRouter" : {
+          "hostname" : "5c2d6b69-c7f0-41bd-b69b-240695369d6e.ingress.us-south.logs.cloud.ibm.com",
+          "iamApiKey" : "ab00e3c09p1d4ff7fff9f04c12183413"
+        }
+      }
+    },
+    "workload" : {
+      "type" : "workload"
+    }
+  })
+}
+
+# Package the encrypted contract as cloud-init ISO for HPCR on KVM
+resource "hpcr_cloudinit_iso" "ciiso" {
+  user_data = hpcr_contract_encrypted.contract.rendered
+  meta_data = "local-hostname: hpcr-kvm\n"
+  path      = "${path.module}/build/ciiso.iso"
+}
+
+output "ciiso_sha256" {
+  value = hpcr_cloudinit_iso.ciiso.sha256_out
+}
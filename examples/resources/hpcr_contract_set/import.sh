@@ -0,0 +1 @@
+terraform import hpcr_contract_set.example "contract_set:node-1,node-2,node-3"
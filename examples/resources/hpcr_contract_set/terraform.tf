@@ -0,0 +1,76 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+variable "instances" {
+  type    = list(string)
+  default = ["node-1", "node-2", "node-3"]
+}
+
+resource "hpcr_tgz" "contract" {
+  folder = "pods"
+}
+
+locals {
+  # base contract in clear text, shared by all instances
+  contract = yamlencode({
+    "env" : {
+      "type" : "env",
+      "logging" : {
+        "logRouter" : {
+          "hostname" : "5c2d6b69-c7f0-41bd-b69b-240695369d6e.ingress.us-south.logs.cloud.ibm.com",
+          "iamApiKey" : "ab00e3c09p1d4ff7fff9f04c12183413",
+          "tags" : ["fleet"]
+        }
+      },
+      "env" : {
+        "LOG_LEVEL" : "info"
+      }
+    },
+    "workload" : {
+      "type" : "workload",
+      "play" : {
+        "archive" : hpcr_tgz.contract.rendered
+      }
+    }
+  })
+}
+
+resource "hpcr_contract_set" "fleet" {
+  contract = local.contract
+  privkey  = file("./cert/private.pem")
+
+  # env overrides per instance, deep merged into the env section of the contract
+  overrides = {
+    for name in var.instances : name => yamlencode({
+      "logging" : {
+        "logRouter" : {
+          "tags" : ["fleet", name]
+        }
+      },
+      "env" : {
+        "HOSTNAME" : name
+      },
+      "volumes" : {
+        "data" : {
+          "seed" : "seed-${name}"
+        }
+      }
+    })
+  }
+}
+
+resource "local_file" "contract_rendered" {
+  for_each = hpcr_contract_set.fleet.rendered
+  filename = "${path.module}/build/${each.key}.yaml"
+  content  = each.value
+}
+
+output "contract_sha256_out" {
+  value = hpcr_contract_set.fleet.sha256_out
+}
@@ -31,6 +31,10 @@ resource "hpcr_json" "json_data2" {
   JSON
 }
 
+resource "hpcr_json" "json_data3" {
+  value = ["hello", { "b" : 2, "a" : 1 }]
+}
+
 output "json1_rendered" {
   value = hpcr_json.json_data1.rendered
 }
@@ -54,3 +58,7 @@ output "json2_sha256_in" {
 output "json2_sha256_out" {
   value = hpcr_json.json_data2.sha256_out
 }
+
+output "json3_rendered" {
+  value = hpcr_json.json_data3.rendered
+}
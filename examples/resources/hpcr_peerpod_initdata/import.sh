@@ -0,0 +1 @@
+terraform import hpcr_peerpod_initdata.example "peerpod_initdata:${DIGEST}"
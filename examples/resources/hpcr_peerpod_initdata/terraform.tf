@@ -0,0 +1,67 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+
+    kubernetes = {
+      source  = "hashicorp/kubernetes"
+      version = ">= 2.0.0"
+    }
+  }
+}
+
+resource "hpcr_contract_encrypted" "contract" {
+  platform = "hpcc-peerpod"
+  contract = yamlencode({
+    "env" : {
+      "type" : "env",
+      "logging" : {
+        "logRouter" : {
+          "hostname" : "5c2d6b69-c7f0-41bd-b69b-240695369d6e.ingress.us-south.logs.cloud.ibm.com",
+          "iamApiKey" : "ab00e3c09p1d4ff7fff9f04c12183413"
+        }
+      }
+    }
+  })
+}
+
+# Wrap the encrypted contract into the initdata of the peer pod
+resource "hpcr_peerpod_initdata" "initdata" {
+  contract = hpcr_contract_encrypted.contract.rendered
+}
+
+# Pass the initdata as pod annotation
+resource "kubernetes_pod" "workload" {
+  metadata {
+    name = "hpcc-workload"
+    annotations = {
+      (hpcr_peerpod_initdata.initdata.annotation_key) = hpcr_peerpod_initdata.initdata.k8s_annotation
+    }
+  }
+
+  spec {
+    runtime_class_name = "kata-remote"
+
+    container {
+      name  = "workload"
+      image = "busybox:latest"
+    }
+  }
+}
+
+# Or provide the initdata document in a ConfigMap
+resource "kubernetes_config_map" "initdata" {
+  metadata {
+    name = "hpcc-initdata"
+  }
+
+  data = {
+    "initdata.toml" = hpcr_peerpod_initdata.initdata.initdata_toml
+  }
+}
+
+output "initdata_digest" {
+  value = hpcr_peerpod_initdata.initdata.digest
+}
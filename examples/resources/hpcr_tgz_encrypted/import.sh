@@ -0,0 +1,2 @@
+# Import with the sha256_in and rendered values of the existing resource, e.g. from an old state file
+terraform import hpcr_tgz_encrypted.example "tgz_encrypted:${SHA256_IN}:${RENDERED}"
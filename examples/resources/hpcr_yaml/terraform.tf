@@ -0,0 +1,39 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+resource "hpcr_yaml" "compose" {
+  yaml = file("./compose/docker-compose.yaml")
+}
+
+resource "hpcr_yaml" "pods" {
+  yaml           = <<-EOT
+    apiVersion: v1
+    kind: Pod
+    metadata:
+      name: app
+    ---
+    apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: app-config
+  EOT
+  multi_document = true
+}
+
+output "yaml_compose_rendered" {
+  value = hpcr_yaml.compose.rendered
+}
+
+output "yaml_compose_sha256_in" {
+  value = hpcr_yaml.compose.sha256_in
+}
+
+output "yaml_pods_rendered" {
+  value = hpcr_yaml.pods.rendered
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    hpcr = {
+      source  = "ibm-hyper-protect/hpcr"
+      version = ">= 1.2.0"
+    }
+  }
+}
+
+resource "hpcr_yaml_encrypted" "env" {
+  yaml = <<-EOT
+    type: env
+    logging:
+      logRouter:
+        hostname: 5c2d6b69-c7f0-41bd-b69b-240695369d6e.ingress.us-south.logs.cloud.ibm.com
+        iamApiKey: ab00e3c09p1d4ff7fff9f04c12183413
+  EOT
+}
+
+resource "hpcr_yaml_encrypted" "env_cert" {
+  yaml     = "type: env\n"
+  cert     = file("./cert/encrypt.crt")
+  platform = "hpvs"
+}
+
+output "yaml_env_rendered" {
+  value = hpcr_yaml_encrypted.env.rendered
+}
+
+output "yaml_env_sha256_in" {
+  value = hpcr_yaml_encrypted.env.sha256_in
+}
+
+output "yaml_env_cert_rendered" {
+  value = hpcr_yaml_encrypted.env_cert.rendered
+}
@@ -16,11 +16,19 @@ package datasources
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/base64"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -38,13 +46,42 @@ func NewAttestationDataSource() datasource.DataSource {
 type AttestationDataSource struct{}
 
 type AttestationDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Attestation types.String `tfsdk:"attestation"`
-	PrivKey     types.String `tfsdk:"privkey"`
-	Password    types.String `tfsdk:"password"`
-	Cert        types.String `tfsdk:"cert"`
-	Signature   types.String `tfsdk:"signature"`
-	Checksums   types.Map    `tfsdk:"checksums"`
+	ID               types.String `tfsdk:"id"`
+	Attestation      types.String `tfsdk:"attestation"`
+	PrivKey          types.String `tfsdk:"privkey"`
+	Password         types.String `tfsdk:"password"`
+	Cert             types.String `tfsdk:"cert"`
+	Signature        types.String `tfsdk:"signature"`
+	TrustBundle      types.String `tfsdk:"trust_bundle"`
+	CRLs             types.List   `tfsdk:"crls"`
+	AllowSelfSigned  types.Bool   `tfsdk:"allow_self_signed"`
+	Expected         types.Map    `tfsdk:"expected"`
+	Strict           types.Bool   `tfsdk:"strict"`
+	MinAlgorithm     types.String `tfsdk:"min_algorithm"`
+	ReportFormat     types.String `tfsdk:"report_format"`
+	ReportPath       types.String `tfsdk:"report_path"`
+	Checksums        types.Map    `tfsdk:"checksums"`
+	Algorithm        types.String `tfsdk:"algorithm"`
+	Metadata         types.Map    `tfsdk:"metadata"`
+	Records          types.List   `tfsdk:"records"`
+	SignerSubject    types.String `tfsdk:"signer_subject"`
+	SignerValidUntil types.String `tfsdk:"signer_valid_until"`
+	Verified         types.Bool   `tfsdk:"verified"`
+	Mismatches       types.List   `tfsdk:"mismatches"`
+	ReportJSON       types.String `tfsdk:"report_json"`
+	GeneratedAt      types.String `tfsdk:"generated_at"`
+}
+
+type attestationRecordModel struct {
+	Filename  types.String `tfsdk:"filename"`
+	Checksum  types.String `tfsdk:"checksum"`
+	Algorithm types.String `tfsdk:"algorithm"`
+}
+
+var attestationRecordAttrTypes = map[string]attr.Type{
+	"filename":  types.StringType,
+	"checksum":  types.StringType,
+	"algorithm": types.StringType,
 }
 
 func (d *AttestationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -88,12 +125,127 @@ func (d *AttestationDataSource) Schema(ctx context.Context, req datasource.Schem
 				Description:         "Base64-encoded signature of the attestation records (content of se-signature.bin, base64-encoded)",
 				Optional:            true,
 			},
+			"trust_bundle": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates trusted to issue the attestation certificate. When set, the chain and validity period of `cert` are validated before the signature is verified. Intermediate certificates can be appended to `cert` after the attestation certificate. " +
+					"The certificate is not checked against the image version of the attestation record.",
+				Description: "PEM encoded CA certificates trusted to issue the attestation certificate",
+				Optional:    true,
+			},
+			"crls": schema.ListAttribute{
+				MarkdownDescription: "PEM encoded certificate revocation lists checked during the validation of the certificate chain. Requires `trust_bundle`.",
+				Description:         "PEM encoded certificate revocation lists checked during the validation of the certificate chain",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"allow_self_signed": schema.BoolAttribute{
+				MarkdownDescription: "Accept a self-signed `cert` when no `trust_bundle` is provided, e.g. for tests. Defaults to `false`, so that a self-signed certificate fails the data source.",
+				Description:         "Accept a self-signed attestation certificate when no trust bundle is provided",
+				Optional:            true,
+			},
+			"expected": schema.MapAttribute{
+				MarkdownDescription: "Map from filename to the expected checksum, for example `hpcr_contract_encrypted.sha256_out` for `cidata/user-data`. When set, `verified` and `mismatches` report the result of the comparison.",
+				Description:         "Map from filename to the expected checksum",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"strict": schema.BoolAttribute{
+				MarkdownDescription: "Fail the data source if any expected checksum does not match or the file is missing from the attestation record. Defaults to `false`.",
+				Description:         "Fail the data source if any expected checksum does not match",
+				Optional:            true,
+			},
+			"min_algorithm": schema.StringAttribute{
+				MarkdownDescription: "Minimum digest algorithm required for every checksum of the attestation record, one of `sha256`, `sha384` or `sha512`. The data source fails if a weaker checksum is found.",
+				Description:         "Minimum digest algorithm required for every checksum of the attestation record",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DigestSHA256, common.DigestSHA384, common.DigestSHA512),
+				},
+			},
+			"report_format": schema.StringAttribute{
+				MarkdownDescription: "Format of `report_json`, either `json` for a plain report or `in-toto` for an in-toto v1 statement whose subjects are the measured files. Defaults to `json`.",
+				Description:         "Format of the attestation report, either json or in-toto",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.ReportFormatJSON, common.ReportFormatInToto),
+				},
+			},
+			"report_path": schema.StringAttribute{
+				MarkdownDescription: "Path of a file the attestation report is written to",
+				Description:         "Path of a file the attestation report is written to",
+				Optional:            true,
+			},
 			"checksums": schema.MapAttribute{
 				MarkdownDescription: "Map from filename to checksum of the attestation record",
 				Description:         "Map from filename to checksum of the attestation record",
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Digest algorithm of the attestation checksums, detected from the checksum length (`sha256`, `sha384` or `sha512`). If the records use several algorithms, the weakest one is reported.",
+				Description:         "Digest algorithm of the attestation checksums",
+				Computed:            true,
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Header values of the attestation record with snake_case keys, e.g. `image_version`, `machine_type_plant_serial` or `hkd_is_valid_until`",
+				Description:         "Header values of the attestation record",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "Checksum entries of the attestation record in their original order",
+				Description:         "Checksum entries of the attestation record",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"filename": schema.StringAttribute{
+							MarkdownDescription: "Name of the measured file or component",
+							Description:         "Name of the measured file or component",
+							Computed:            true,
+						},
+						"checksum": schema.StringAttribute{
+							MarkdownDescription: "Checksum of the measured file or component",
+							Description:         "Checksum of the measured file or component",
+							Computed:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "Digest algorithm of the checksum",
+							Description:         "Digest algorithm of the checksum",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"signer_subject": schema.StringAttribute{
+				MarkdownDescription: "Subject of the attestation certificate. Null if `cert` is not set.",
+				Description:         "Subject of the attestation certificate",
+				Computed:            true,
+			},
+			"signer_valid_until": schema.StringAttribute{
+				MarkdownDescription: "End of the validity period of the attestation certificate in RFC 3339 format. Null if `cert` is not set.",
+				Description:         "End of the validity period of the attestation certificate",
+				Computed:            true,
+			},
+			"report_json": schema.StringAttribute{
+				MarkdownDescription: "Attestation report for auditors, with the generation time, processing inputs, certificate fingerprint, signature status and records.",
+				Description:         "Attestation report for auditors",
+				Computed:            true,
+			},
+			"generated_at": schema.StringAttribute{
+				MarkdownDescription: "Time the attestation report was generated in RFC 3339 format, as recorded in `report_json`. Kept from the file at `report_path` while the report does not change.",
+				Description:         "Time the attestation report was generated",
+				Computed:            true,
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether all `expected` checksums match the attestation record. Null if `expected` is not set.",
+				Description:         "Whether all expected checksums match the attestation record",
+				Computed:            true,
+			},
+			"mismatches": schema.ListAttribute{
+				MarkdownDescription: "Sorted filenames from `expected` whose checksum differs or that are missing from the attestation record",
+				Description:         "Filenames whose checksum differs from the expected value",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -123,6 +275,75 @@ func (d *AttestationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
+	trustBundle := data.TrustBundle.ValueString()
+	var crls []string
+	if !data.CRLs.IsNull() {
+		resp.Diagnostics.Append(data.CRLs.ElementsAs(ctx, &crls, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The certificate chain is only validated for a certificate that verifies the signature
+	if trustBundle != "" && (cert == "" || signature == "") {
+		resp.Diagnostics.AddError(
+			"Invalid attestation configuration",
+			"'trust_bundle' requires 'cert' and 'signature' to be provided.",
+		)
+		return
+	}
+	if len(crls) > 0 && trustBundle == "" {
+		resp.Diagnostics.AddError(
+			"Invalid attestation configuration",
+			"'crls' requires 'trust_bundle' to be provided.",
+		)
+		return
+	}
+
+	// Validate the attestation certificate before it is used to verify the signature
+	signerSubject := types.StringNull()
+	signerValidUntil := types.StringNull()
+	var signer *x509.Certificate
+	if cert != "" {
+		if trustBundle != "" {
+			verifiedSigner, err := common.VerifyCertificateChain(cert, trustBundle, crls, time.Now())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Attestation certificate validation failed",
+					fmt.Sprintf("Error validating attestation certificate: %s", err.Error()),
+				)
+				return
+			}
+			signer = verifiedSigner
+		} else {
+			certs, err := common.ParseCertificates(cert)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid attestation certificate",
+					fmt.Sprintf("Error parsing attestation certificate: %s", err.Error()),
+				)
+				return
+			}
+			signer = certs[0]
+
+			if common.IsSelfSigned(signer) && !data.AllowSelfSigned.ValueBool() {
+				resp.Diagnostics.AddError(
+					"Self-signed attestation certificate",
+					"The attestation certificate is self-signed. Provide the IBM CA certificates as 'trust_bundle' to validate it, or set 'allow_self_signed' to accept it.",
+				)
+				return
+			}
+
+			resp.Diagnostics.AddWarning(
+				"Attestation certificate not validated",
+				"No 'trust_bundle' was provided, so the attestation certificate is not validated against a trusted CA. Any certificate that is not self-signed is accepted.",
+			)
+		}
+
+		signerSubject = types.StringValue(signer.Subject.String())
+		signerValidUntil = types.StringValue(signer.NotAfter.UTC().Format(time.RFC3339))
+	}
+
 	var attestationRecords string
 	var err error
 
@@ -165,43 +386,165 @@ func (d *AttestationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		tflog.Debug(ctx, "Attestation signature verification successful")
 	}
 
-	filteredAttestation := common.FilterChecksum(attestationRecords)
+	parsedAttestation, err := common.ParseAttestation(attestationRecords)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to parse attestation records",
-			fmt.Sprintf("Error parsing attestation records as JSON: %s", err.Error()),
+			fmt.Sprintf("Error parsing attestation records: %s", err.Error()),
 		)
 		return
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Filtered attestation records - %s", filteredAttestation))
+	tflog.Debug(ctx, fmt.Sprintf("Parsed %d attestation records", len(parsedAttestation.Records)))
 
-	// Convert map[string]interface{} to map[string]string for Terraform
-	checksumStrMap := make(map[string]string)
-	for key, value := range filteredAttestation {
-		checksumStrMap[value] = fmt.Sprintf("%v", key)
+	// Enforce the minimum digest algorithm
+	if minAlgorithm := data.MinAlgorithm.ValueString(); minAlgorithm != "" {
+		if err := parsedAttestation.RequireAlgorithm(minAlgorithm); err != nil {
+			resp.Diagnostics.AddError(
+				"Attestation digest algorithm too weak",
+				fmt.Sprintf("Error checking digest algorithm: %s", err.Error()),
+			)
+			return
+		}
 	}
 
-	// Convert to Terraform types.Map
+	checksumStrMap := parsedAttestation.Checksums()
+
+	// Convert to Terraform types
 	checksums, diags := types.MapValueFrom(ctx, types.StringType, checksumStrMap)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Generate UUID for the data source ID
-	id, err := common.GenerateID()
+	metadata, diags := types.MapValueFrom(ctx, types.StringType, parsedAttestation.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordValues := make([]attestationRecordModel, 0, len(parsedAttestation.Records))
+	for _, record := range parsedAttestation.Records {
+		recordValues = append(recordValues, attestationRecordModel{
+			Filename:  types.StringValue(record.Filename),
+			Checksum:  types.StringValue(record.Checksum),
+			Algorithm: types.StringValue(record.Algorithm),
+		})
+	}
+	records, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: attestationRecordAttrTypes}, recordValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare the checksums with the expected values
+	verified := types.BoolNull()
+	mismatches := types.ListNull(types.StringType)
+	var expectedMap map[string]string
+	var mismatchList []string
+	if !data.Expected.IsNull() {
+		resp.Diagnostics.Append(data.Expected.ElementsAs(ctx, &expectedMap, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		mismatchList = common.VerifyChecksums(checksumStrMap, expectedMap)
+		if data.Strict.ValueBool() && len(mismatchList) > 0 {
+			resp.Diagnostics.AddError(
+				"Attestation checksum verification failed",
+				fmt.Sprintf("Checksums do not match the expected values or are missing for: %s", strings.Join(mismatchList, ", ")),
+			)
+			return
+		}
+
+		verified = types.BoolValue(len(mismatchList) == 0)
+		mismatches, diags = types.ListValueFrom(ctx, types.StringType, mismatchList)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Assemble the attestation report
+	report := &common.AttestationReport{
+		Inputs: common.AttestationReportInputs{
+			Encrypted:      privateKey != "",
+			SignatureInput: signature != "",
+			TrustBundle:    trustBundle != "",
+			CRLs:           len(crls),
+			MinAlgorithm:   data.MinAlgorithm.ValueString(),
+			Expected:       expectedMap,
+		},
+		Signature: common.ReportSignatureNotVerified,
+		Algorithm: parsedAttestation.Algorithm(),
+		Metadata:  parsedAttestation.Metadata,
+		Records:   parsedAttestation.Records,
+	}
+	if signer != nil {
+		report.Certificate = common.NewAttestationReportSigner(signer, trustBundle != "")
+		report.Signature = common.ReportSignatureVerified
+	}
+	if !verified.IsNull() {
+		report.Verification = &common.AttestationReportResult{
+			Verified:   verified.ValueBool(),
+			Mismatches: mismatchList,
+		}
+	}
+
+	// Keep the generation time of a report that is written again unchanged
+	report.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+	if reportPath := data.ReportPath.ValueString(); reportPath != "" {
+		if previous, err := os.ReadFile(reportPath); err == nil {
+			if generatedAt, ok := report.PreviousGeneratedAt(string(previous), data.ReportFormat.ValueString()); ok {
+				report.GeneratedAt = generatedAt
+			}
+		}
+	}
+
+	reportJSON, err := report.Render(data.ReportFormat.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to generate ID",
-			fmt.Sprintf("Error generating ID for data source: %s", err.Error()),
+			"Failed to create attestation report",
+			fmt.Sprintf("Error creating attestation report: %s", err.Error()),
 		)
 		return
 	}
 
+	if reportPath := data.ReportPath.ValueString(); reportPath != "" {
+		if err := os.WriteFile(reportPath, []byte(reportJSON), 0600); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to write attestation report",
+				fmt.Sprintf("Error writing attestation report to %s: %s", reportPath, err.Error()),
+			)
+			return
+		}
+	}
+
+	// Derive the data source ID from the attestation records and their verification material
+	id := attestationID(attestationData, cert, signature, trustBundle, crls)
+
 	// Set the computed values
 	data.Checksums = checksums
+	data.Algorithm = types.StringValue(parsedAttestation.Algorithm())
+	data.Metadata = metadata
+	data.SignerSubject = signerSubject
+	data.SignerValidUntil = signerValidUntil
+	data.Records = records
+	data.Verified = verified
+	data.Mismatches = mismatches
+	data.ReportJSON = types.StringValue(reportJSON)
+	data.GeneratedAt = types.StringValue(report.GeneratedAt)
 	data.ID = types.StringValue(id)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// attestationID derives the data source ID from the attestation records, the
+// certificate and signature they are verified with and the trust material of
+// the certificate. The order of the CRLs does not matter for the validation,
+// so they are sorted.
+func attestationID(attestationData, cert, signature, trustBundle string, crls []string) string {
+	sortedCRLs := append([]string(nil), crls...)
+	sort.Strings(sortedCRLs)
+	return common.StableID(append([]string{attestationData, cert, signature, trustBundle}, sortedCRLs...)...)
+}
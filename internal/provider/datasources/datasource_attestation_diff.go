@@ -0,0 +1,193 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/contract-go/v2/attestation"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ datasource.DataSource = &AttestationDiffDataSource{}
+
+func NewAttestationDiffDataSource() datasource.DataSource {
+	return &AttestationDiffDataSource{}
+}
+
+type AttestationDiffDataSource struct{}
+
+type AttestationDiffDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OldAttestation types.String `tfsdk:"old_attestation"`
+	OldPrivKey     types.String `tfsdk:"old_privkey"`
+	OldPassword    types.String `tfsdk:"old_password"`
+	NewAttestation types.String `tfsdk:"new_attestation"`
+	NewPrivKey     types.String `tfsdk:"new_privkey"`
+	NewPassword    types.String `tfsdk:"new_password"`
+	Added          types.List   `tfsdk:"added"`
+	Removed        types.List   `tfsdk:"removed"`
+	Changed        types.List   `tfsdk:"changed"`
+}
+
+func (d *AttestationDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attestation_diff"
+}
+
+func (d *AttestationDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compares two HPCR attestation records (encrypted or unencrypted) and reports the measured files that were added, removed or changed.",
+		Description:         "Compares two attestation records and reports the measured files that changed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier",
+			},
+			"old_attestation": schema.StringAttribute{
+				MarkdownDescription: "The encrypted or unencrypted attestation record of the previous deployment",
+				Description:         "The encrypted or unencrypted attestation record of the previous deployment",
+				Required:            true,
+			},
+			"old_privkey": schema.StringAttribute{
+				MarkdownDescription: "Private key used to decrypt `old_attestation`. If missing the attestation record is assumed to be unencrypted.",
+				Description:         "Private key used to decrypt the previous attestation record",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"old_password": schema.StringAttribute{
+				MarkdownDescription: "Password used to decrypt `old_privkey`",
+				Description:         "Password used to decrypt the private key of the previous attestation record",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"new_attestation": schema.StringAttribute{
+				MarkdownDescription: "The encrypted or unencrypted attestation record of the current deployment",
+				Description:         "The encrypted or unencrypted attestation record of the current deployment",
+				Required:            true,
+			},
+			"new_privkey": schema.StringAttribute{
+				MarkdownDescription: "Private key used to decrypt `new_attestation`. If missing the attestation record is assumed to be unencrypted.",
+				Description:         "Private key used to decrypt the current attestation record",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"new_password": schema.StringAttribute{
+				MarkdownDescription: "Password used to decrypt `new_privkey`",
+				Description:         "Password used to decrypt the private key of the current attestation record",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"added": schema.ListAttribute{
+				MarkdownDescription: "Sorted filenames that are only measured in the new attestation record",
+				Description:         "Filenames that are only measured in the new attestation record",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"removed": schema.ListAttribute{
+				MarkdownDescription: "Sorted filenames that are only measured in the old attestation record",
+				Description:         "Filenames that are only measured in the old attestation record",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"changed": schema.ListAttribute{
+				MarkdownDescription: "Sorted filenames whose checksum differs between the two attestation records",
+				Description:         "Filenames whose checksum differs between the two attestation records",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AttestationDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AttestationDiffDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oldChecksums, err := attestationChecksums(data.OldAttestation.ValueString(), data.OldPrivKey.ValueString(), data.OldPassword.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read old attestation record",
+			fmt.Sprintf("Error reading old attestation record: %s", err.Error()),
+		)
+		return
+	}
+
+	newChecksums, err := attestationChecksums(data.NewAttestation.ValueString(), data.NewPrivKey.ValueString(), data.NewPassword.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read new attestation record",
+			fmt.Sprintf("Error reading new attestation record: %s", err.Error()),
+		)
+		return
+	}
+
+	added, removed, changed := common.DiffChecksums(oldChecksums, newChecksums)
+
+	// Convert to Terraform types.List
+	addedList, diags := types.ListValueFrom(ctx, types.StringType, added)
+	resp.Diagnostics.Append(diags...)
+	removedList, diags := types.ListValueFrom(ctx, types.StringType, removed)
+	resp.Diagnostics.Append(diags...)
+	changedList, diags := types.ListValueFrom(ctx, types.StringType, changed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Derive the data source ID from the compared attestation records
+	id := common.StableID(data.OldAttestation.ValueString(), data.NewAttestation.ValueString())
+
+	// Set the computed values
+	data.Added = addedList
+	data.Removed = removedList
+	data.Changed = changedList
+	data.ID = types.StringValue(id)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// attestationChecksums decrypts an attestation record if a private key is
+// provided and returns the map from filename to checksum of its records.
+func attestationChecksums(attestationData, privateKey, password string) (map[string]string, error) {
+	attestationRecords := attestationData
+	if privateKey != "" {
+		decrypted, err := attestation.HpcrGetAttestationRecords(attestationData, privateKey, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt attestation: %v", err)
+		}
+		attestationRecords = decrypted
+	}
+
+	parsedAttestation, err := common.ParseAttestation(attestationRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsedAttestation.Checksums(), nil
+}
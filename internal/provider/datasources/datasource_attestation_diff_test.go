@@ -0,0 +1,132 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestAttestationDiffDataSource_Metadata(t *testing.T) {
+	ds := NewAttestationDiffDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_attestation_diff" {
+		t.Errorf("Expected TypeName to be 'hpcr_attestation_diff', got '%s'", resp.TypeName)
+	}
+}
+
+func TestAttestationDiffDataSource_Schema(t *testing.T) {
+	ds := NewAttestationDiffDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has required attributes
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	requiredAttrs := []string{"id", "old_attestation", "old_privkey", "old_password", "new_attestation", "new_privkey", "new_password", "added", "removed", "changed"}
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected schema to have attribute '%s'", attr)
+		}
+	}
+
+	// Verify attestation records are required
+	for _, attr := range []string{"old_attestation", "new_attestation"} {
+		if resp.Schema.Attributes[attr].IsRequired() == false {
+			t.Errorf("Expected '%s' attribute to be required", attr)
+		}
+	}
+
+	// Verify private keys and passwords are optional and sensitive
+	for _, attr := range []string{"old_privkey", "old_password", "new_privkey", "new_password"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+		if resp.Schema.Attributes[attr].IsSensitive() == false {
+			t.Errorf("Expected '%s' attribute to be marked as sensitive", attr)
+		}
+	}
+
+	// Verify computed attributes
+	computedAttrs := []string{"id", "added", "removed", "changed"}
+	for _, attr := range computedAttrs {
+		if resp.Schema.Attributes[attr].IsComputed() == false {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+}
+
+func TestNewAttestationDiffDataSource(t *testing.T) {
+	ds := NewAttestationDiffDataSource()
+	if ds == nil {
+		t.Fatal("NewAttestationDiffDataSource should not return nil")
+	}
+
+	// Verify it implements the DataSource interface
+	var _ datasource.DataSource = &AttestationDiffDataSource{}
+}
+
+func TestAttestationDiffDataSource_SchemaDescriptions(t *testing.T) {
+	ds := NewAttestationDiffDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has descriptions
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	// Verify attributes have descriptions (either Description or MarkdownDescription)
+	for name, attr := range resp.Schema.Attributes {
+		desc := attr.GetDescription()
+		mdDesc := attr.GetMarkdownDescription()
+		if desc == "" && mdDesc == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
+
+func TestAttestationChecksums_Unencrypted(t *testing.T) {
+	record := "25.11.0\nc5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4 cidata/user-data\n"
+
+	checksums, err := attestationChecksums(record, "", "")
+	if err != nil {
+		t.Fatalf("attestationChecksums() failed: %v", err)
+	}
+	if checksums["cidata/user-data"] != "c5d20c46d921fde43d7d4106ff92cafd104331250ff382d48f42f31c033accc4" {
+		t.Errorf("Unexpected checksums: %v", checksums)
+	}
+}
@@ -16,9 +16,17 @@ package datasources
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
 )
 
 func TestAttestationDataSource_Metadata(t *testing.T) {
@@ -49,7 +57,7 @@ func TestAttestationDataSource_Schema(t *testing.T) {
 		t.Fatal("Schema attributes should not be nil")
 	}
 
-	requiredAttrs := []string{"id", "attestation", "privkey", "cert", "signature", "checksums"}
+	requiredAttrs := []string{"id", "attestation", "privkey", "cert", "signature", "trust_bundle", "crls", "allow_self_signed", "expected", "strict", "min_algorithm", "report_format", "report_path", "checksums", "algorithm", "metadata", "records", "signer_subject", "signer_valid_until", "verified", "mismatches", "report_json"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("Expected schema to have attribute '%s'", attr)
@@ -74,8 +82,22 @@ func TestAttestationDataSource_Schema(t *testing.T) {
 		t.Error("Expected 'cert' attribute to be optional")
 	}
 
+	// Verify expected and strict are optional
+	for _, attr := range []string{"trust_bundle", "crls", "allow_self_signed", "expected", "strict", "min_algorithm", "report_format", "report_path"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
+	// Verify that the enumerated attributes are validated at plan time
+	for _, attr := range []string{"min_algorithm", "report_format"} {
+		if len(resp.Schema.Attributes[attr].(schema.StringAttribute).Validators) == 0 {
+			t.Errorf("Expected '%s' attribute to have a validator", attr)
+		}
+	}
+
 	// Verify computed attributes
-	computedAttrs := []string{"id", "checksums"}
+	computedAttrs := []string{"id", "checksums", "algorithm", "metadata", "records", "signer_subject", "signer_valid_until", "verified", "mismatches", "report_json"}
 	for _, attr := range computedAttrs {
 		if resp.Schema.Attributes[attr].IsComputed() == false {
 			t.Errorf("Expected '%s' attribute to be computed", attr)
@@ -204,3 +226,109 @@ func TestAttestationDataSource_SchemaDescriptions(t *testing.T) {
 		}
 	}
 }
+
+func TestAttestationDataSource_ReportStable(t *testing.T) {
+	records, err := os.ReadFile("../../../examples/datasources/hpcr_attestation_diff/records/se-checksums-new.txt")
+	if err != nil {
+		t.Fatalf("Failed to read attestation records: %v", err)
+	}
+	reportPath := filepath.Join(t.TempDir(), "attestation-report.json")
+	config := map[string]tftypes.Value{
+		"attestation": tftypes.NewValue(tftypes.String, string(records)),
+		"report_path": tftypes.NewValue(tftypes.String, reportPath),
+	}
+
+	var first, second AttestationDataSourceModel
+	testRead(t, NewAttestationDataSource(), config, &first)
+
+	var report common.AttestationReport
+	if err := json.Unmarshal([]byte(first.ReportJSON.ValueString()), &report); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+	if report.GeneratedAt == "" || report.GeneratedAt != first.GeneratedAt.ValueString() {
+		t.Errorf("Expected the report to contain generated_at %q, got %q", first.GeneratedAt.ValueString(), report.GeneratedAt)
+	}
+
+	// A report written earlier for the same inputs keeps its generation time
+	const generatedAt = "2026-01-02T03:04:05Z"
+	written := strings.Replace(first.ReportJSON.ValueString(), report.GeneratedAt, generatedAt, 1)
+	if err := os.WriteFile(reportPath, []byte(written), 0600); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	testRead(t, NewAttestationDataSource(), config, &second)
+
+	if second.GeneratedAt.ValueString() != generatedAt || second.ReportJSON.ValueString() != written {
+		t.Errorf("Expected the report of %s to be kept, got:\n%s", generatedAt, second.ReportJSON.ValueString())
+	}
+}
+
+func TestAttestationDataSource_TrustBundleRequiresCertAndSignature(t *testing.T) {
+	var data AttestationDataSourceModel
+	diags := testReadDiagnostics(t, NewAttestationDataSource(), map[string]tftypes.Value{
+		"attestation":  tftypes.NewValue(tftypes.String, "25.11.0\n"),
+		"trust_bundle": tftypes.NewValue(tftypes.String, "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"),
+	}, &data)
+
+	if !diags.HasError() {
+		t.Fatal("Expected an error for trust_bundle without cert and signature")
+	}
+	if detail := diags.Errors()[0].Detail(); detail != "'trust_bundle' requires 'cert' and 'signature' to be provided." {
+		t.Errorf("Unexpected error: %s", detail)
+	}
+}
+
+func TestAttestationDataSource_SelfSignedCert(t *testing.T) {
+	_, _, selfSignedPEM := newTestSigningCert(t, "attestation", nil, nil)
+
+	tests := []struct {
+		name            string
+		allowSelfSigned tftypes.Value
+		expectRejected  bool
+	}{
+		{"default", tftypes.NewValue(tftypes.Bool, nil), true},
+		{"not allowed", tftypes.NewValue(tftypes.Bool, false), true},
+		{"allowed", tftypes.NewValue(tftypes.Bool, true), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data AttestationDataSourceModel
+			diags := testReadDiagnostics(t, NewAttestationDataSource(), map[string]tftypes.Value{
+				"attestation":       tftypes.NewValue(tftypes.String, "25.11.0\n"),
+				"cert":              tftypes.NewValue(tftypes.String, selfSignedPEM),
+				"signature":         tftypes.NewValue(tftypes.String, "c2lnbmF0dXJl"),
+				"allow_self_signed": tt.allowSelfSigned,
+			}, &data)
+
+			rejected := false
+			for _, d := range diags.Errors() {
+				if d.Summary() == "Self-signed attestation certificate" {
+					rejected = true
+				}
+			}
+			if rejected != tt.expectRejected {
+				t.Errorf("Expected rejected = %v, got diagnostics: %v", tt.expectRejected, diags)
+			}
+		})
+	}
+}
+
+func TestAttestationID(t *testing.T) {
+	base := attestationID("records", "cert", "signature", "bundle", []string{"crl-a", "crl-b"})
+
+	if id := attestationID("records", "cert", "signature", "bundle", []string{"crl-b", "crl-a"}); id != base {
+		t.Error("Expected the ID not to depend on the order of the CRLs")
+	}
+
+	variants := map[string]string{
+		"cert":         attestationID("records", "other", "signature", "bundle", []string{"crl-a", "crl-b"}),
+		"signature":    attestationID("records", "cert", "other", "bundle", []string{"crl-a", "crl-b"}),
+		"trust_bundle": attestationID("records", "cert", "signature", "other", []string{"crl-a", "crl-b"}),
+		"crls":         attestationID("records", "cert", "signature", "bundle", []string{"crl-a"}),
+	}
+	for name, id := range variants {
+		if id == base {
+			t.Errorf("Expected the ID to change with %s", name)
+		}
+	}
+}
@@ -38,13 +38,16 @@ func NewEncryptionCertDataSource() datasource.DataSource {
 type EncryptionCertDataSource struct{}
 
 type EncryptionCertDataSourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Certs        types.Map    `tfsdk:"certs"`
-	Spec         types.String `tfsdk:"spec"`
-	Cert         types.String `tfsdk:"cert"`
-	ExpiryDays   types.String `tfsdk:"expiry"`
-	ExpiryStatus types.String `tfsdk:"status"`
-	Version      types.String `tfsdk:"version"`
+	ID             types.String `tfsdk:"id"`
+	Certs          types.Map    `tfsdk:"certs"`
+	Spec           types.String `tfsdk:"spec"`
+	Cert           types.String `tfsdk:"cert"`
+	ExpiryDays     types.String `tfsdk:"expiry"`
+	ExpiryStatus   types.String `tfsdk:"status"`
+	Version        types.String `tfsdk:"version"`
+	LockFile       types.String `tfsdk:"lock_file"`
+	LockKey        types.String `tfsdk:"lock_key"`
+	LockGeneration types.Int64  `tfsdk:"lock_generation"`
 }
 
 func (d *EncryptionCertDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -74,6 +77,21 @@ func (d *EncryptionCertDataSource) Schema(ctx context.Context, req datasource.Sc
 				Description:         "Semantic version range defining the HPCR certificate",
 				Optional:            true,
 			},
+			"lock_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a JSON lock file. The first selected version is recorded in the lock file together with the certificate fingerprint and reused until `spec` or `lock_generation` changes.",
+				Description:         "Path of a JSON lock file that pins the selected version",
+				Optional:            true,
+			},
+			"lock_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the entry in the lock file. Defaults to `encryption_cert:<spec>`.",
+				Description:         "Key of the entry in the lock file",
+				Optional:            true,
+			},
+			"lock_generation": schema.Int64Attribute{
+				MarkdownDescription: "Generation of the lock entry. Change the value to select a new version and update the lock file. Defaults to `0`.",
+				Description:         "Generation of the lock entry",
+				Optional:            true,
+			},
 			"cert": schema.StringAttribute{
 				MarkdownDescription: "Selected certificate content",
 				Description:         "Selected certificate",
@@ -168,6 +186,36 @@ func (d *EncryptionCertDataSource) Read(ctx context.Context, req datasource.Read
 	sort.Sort(sort.Reverse(semver.Collection(matchingVersions)))
 	selectedVersion := matchingVersions[0].String()
 
+	// Reuse the version recorded in the lock file
+	lockFile := data.LockFile.ValueString()
+	lockKey := data.LockKey.ValueString()
+	if lockKey == "" {
+		lockKey = "encryption_cert:" + spec
+	}
+	generation := data.LockGeneration.ValueInt64()
+	var lockEntry common.LockEntry
+	locked := false
+	if lockFile != "" {
+		lock, err := common.ReadLockFile(lockFile)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read lock file",
+				err.Error(),
+			)
+			return
+		}
+		if lockEntry, locked = lock.Lookup(lockKey, generation, spec); locked {
+			if _, ok := certsMap[lockEntry.Version]; !ok {
+				resp.Diagnostics.AddError(
+					"Locked certificate not available",
+					fmt.Sprintf("The certs map does not contain the certificate version '%s' recorded in '%s'", lockEntry.Version, lockFile),
+				)
+				return
+			}
+			selectedVersion = lockEntry.Version
+		}
+	}
+
 	// Convert certs map to JSON for the library function
 	certsJSON, err := json.Marshal(certsMap)
 	if err != nil {
@@ -188,16 +236,45 @@ func (d *EncryptionCertDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	// Generate UUID for the data source ID
-	id, err := common.GenerateID()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to generate ID",
-			fmt.Sprintf("Error generating ID for data source: %s", err.Error()),
-		)
-		return
+	if lockFile != "" {
+		certs, err := common.ParseCertificates(cert)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to parse encryption certificate",
+				fmt.Sprintf("Error parsing certificate for version '%s': %s", version, err.Error()),
+			)
+			return
+		}
+		fingerprint := common.CertificateFingerprint(certs[0])
+
+		if locked {
+			if err := lockEntry.Verify("", fingerprint); err != nil {
+				resp.Diagnostics.AddError(
+					"Locked certificate changed",
+					fmt.Sprintf("The encryption certificate recorded in '%s' under '%s' changed: %s", lockFile, lockKey, err.Error()),
+				)
+				return
+			}
+		} else {
+			entry := common.LockEntry{
+				Generation:      generation,
+				Spec:            spec,
+				Version:         version,
+				CertFingerprint: fingerprint,
+			}
+			if err := common.RecordLock(lockFile, lockKey, entry); err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to write lock file",
+					err.Error(),
+				)
+				return
+			}
+		}
 	}
 
+	// Derive the data source ID from the spec and the selected certificate
+	id := common.StableID(spec, version, common.GenerateSha256(cert))
+
 	// Set the computed fields
 	data.Version = types.StringValue(version)
 	data.Cert = types.StringValue(cert)
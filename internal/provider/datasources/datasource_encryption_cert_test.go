@@ -69,6 +69,13 @@ func TestEncryptionCertDataSource_Schema(t *testing.T) {
 		t.Error("Expected 'spec' attribute to be optional")
 	}
 
+	// Verify lock attributes are optional
+	for _, attr := range []string{"lock_file", "lock_key", "lock_generation"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
 	// Verify cert is computed
 	certAttr := resp.Schema.Attributes["cert"]
 	if certAttr.IsComputed() == false {
@@ -138,15 +138,8 @@ func (d *EncryptionCertsDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	// Generate UUID for the data source ID
-	id, err := common.GenerateID()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to generate ID",
-			fmt.Sprintf("Error generating ID for data source: %s", err.Error()),
-		)
-		return
-	}
+	// Derive the data source ID from the template, the certificates and the selected versions
+	id := common.StableID(append([]string{template, certsJSON}, versionList...)...)
 
 	// Set the computed fields
 	data.Certs = certsTypeMap
@@ -17,13 +17,17 @@ package datasources
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/image"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
 )
 
 var _ datasource.DataSource = &ImageDataSource{}
@@ -35,13 +39,22 @@ func NewImageDataSource() datasource.DataSource {
 type ImageDataSource struct{}
 
 type ImageDataSourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Images    types.String `tfsdk:"images"`
-	Spec      types.String `tfsdk:"spec"`
-	ImageID   types.String `tfsdk:"image"`
-	ImageName types.String `tfsdk:"name"`
-	Version   types.String `tfsdk:"version"`
-	Sha256    types.String `tfsdk:"sha256"`
+	ID               types.String `tfsdk:"id"`
+	Images           types.String `tfsdk:"images"`
+	Spec             types.String `tfsdk:"spec"`
+	Architecture     types.String `tfsdk:"architecture"`
+	Status           types.String `tfsdk:"status"`
+	Visibility       types.String `tfsdk:"visibility"`
+	OSPattern        types.String `tfsdk:"os_pattern"`
+	CreatedAfter     types.String `tfsdk:"created_after"`
+	DeprecationGrace types.Int64  `tfsdk:"deprecation_grace"`
+	LockFile         types.String `tfsdk:"lock_file"`
+	LockKey          types.String `tfsdk:"lock_key"`
+	LockGeneration   types.Int64  `tfsdk:"lock_generation"`
+	ImageID          types.String `tfsdk:"image"`
+	ImageName        types.String `tfsdk:"name"`
+	Version          types.String `tfsdk:"version"`
+	Sha256           types.String `tfsdk:"sha256"`
 }
 
 func (d *ImageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -68,6 +81,57 @@ func (d *ImageDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Description:         "Semantic version range defining the HPCR image",
 				Optional:            true,
 			},
+			"architecture": schema.StringAttribute{
+				MarkdownDescription: "Only select images with this architecture. Defaults to `s390x`, an empty string disables the check.",
+				Description:         "Only select images with this architecture",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only select images with this status, e.g. `available` or `deprecated`. Defaults to `available`, or `available` and `deprecated` with `deprecation_grace`. An empty string disables the check.",
+				Description:         "Only select images with this status",
+				Optional:            true,
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Only select images with this visibility, e.g. `public` or `private`. Defaults to `public`, an empty string disables the check.",
+				Description:         "Only select images with this visibility",
+				Optional:            true,
+			},
+			"os_pattern": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the operating system name of the image must match. Defaults to `^hyper-protect-[\\w-]+-s390x-hpcr$`, an empty string disables the check.",
+				Description:         "Regular expression the operating system name of the image must match",
+				Optional:            true,
+			},
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: "Only select images created at or after this date, in RFC 3339 format (e.g. `2025-01-01T00:00:00Z`)",
+				Description:         "Only select images created at or after this date, in RFC 3339 format",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.RFC3339(),
+				},
+			},
+			"deprecation_grace": schema.Int64Attribute{
+				MarkdownDescription: "Number of days a newly released image is ignored. The previous image keeps being selected until the new one is older than the grace period.",
+				Description:         "Number of days a newly released image is ignored",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"lock_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a JSON lock file. The first selected version is recorded in the lock file and reused until `spec` or `lock_generation` changes.",
+				Description:         "Path of a JSON lock file that pins the selected version",
+				Optional:            true,
+			},
+			"lock_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the entry in the lock file. Defaults to `image:<spec>`.",
+				Description:         "Key of the entry in the lock file",
+				Optional:            true,
+			},
+			"lock_generation": schema.Int64Attribute{
+				MarkdownDescription: "Generation of the lock entry. Change the value to select a new version and update the lock file. Defaults to `0`.",
+				Description:         "Generation of the lock entry",
+				Optional:            true,
+			},
 			"image": schema.StringAttribute{
 				MarkdownDescription: "ID of the selected image",
 				Description:         "ID of the selected image",
@@ -118,33 +182,190 @@ func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		spec = data.Spec.ValueString()
 	}
 
-	// Select the best matching image using the contract-go library
-	imageID, imageName, checksum, version, err := image.HpcrSelectImage(imageJsonData, spec)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to select image",
-			fmt.Sprintf("Error selecting HPCR image with spec '%s': %s", spec, err.Error()),
-		)
-		return
-	}
+	var selected common.Image
+	var err error
+	if lockFile := data.LockFile.ValueString(); lockFile != "" {
+		lockKey := data.LockKey.ValueString()
+		if lockKey == "" {
+			lockKey = "image:" + spec
+		}
+		generation := data.LockGeneration.ValueInt64()
 
-	// Generate UUID for the data source ID
-	id, err := common.GenerateID()
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to generate ID",
-			fmt.Sprintf("Error generating ID for data source: %s", err.Error()),
-		)
-		return
+		lock, err := common.ReadLockFile(lockFile)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read lock file",
+				err.Error(),
+			)
+			return
+		}
+
+		if entry, ok := lock.Lookup(lockKey, generation, spec); ok {
+			// Reuse exactly the locked image, even if the filters no longer select it
+			selected, err = lockedImage(imageJsonData, entry)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Locked image not available",
+					fmt.Sprintf("Error selecting locked HPCR image version '%s' from '%s': %s", entry.Version, lockFile, err.Error()),
+				)
+				return
+			}
+			if err := entry.Verify(selected.Sha256, ""); err != nil {
+				resp.Diagnostics.AddError(
+					"Locked image changed",
+					fmt.Sprintf("The HPCR image recorded in '%s' under '%s' changed: %s", lockFile, lockKey, err.Error()),
+				)
+				return
+			}
+		} else {
+			selected, err = selectImage(data, imageJsonData, spec)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to select image",
+					fmt.Sprintf("Error selecting HPCR image with spec '%s': %s", spec, err.Error()),
+				)
+				return
+			}
+			entry := common.LockEntry{
+				Generation: generation,
+				Spec:       spec,
+				Version:    selected.Version,
+				Image:      selected.ID,
+				Sha256:     selected.Sha256,
+			}
+			if err := common.RecordLock(lockFile, lockKey, entry); err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to write lock file",
+					err.Error(),
+				)
+				return
+			}
+		}
+	} else {
+		selected, err = selectImage(data, imageJsonData, spec)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to select image",
+				fmt.Sprintf("Error selecting HPCR image with spec '%s': %s", spec, err.Error()),
+			)
+			return
+		}
 	}
 
+	// Derive the data source ID from the spec and the selected image
+	id := common.StableID(spec, selected.ID, selected.Version)
+
 	// Set the computed fields
-	data.ImageID = types.StringValue(imageID)
-	data.ImageName = types.StringValue(imageName)
-	data.Version = types.StringValue(version)
-	data.Sha256 = types.StringValue(checksum)
+	data.ImageID = types.StringValue(selected.ID)
+	data.ImageName = types.StringValue(selected.Name)
+	data.Version = types.StringValue(selected.Version)
+	data.Sha256 = types.StringValue(selected.Sha256)
 	data.ID = types.StringValue(id)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// selectImage selects the best matching image for spec, applying the image
+// filters of the configuration if any is set.
+func selectImage(data ImageDataSourceModel, imagesJSON, spec string) (common.Image, error) {
+	if !imageFilterConfigured(data.Architecture, data.Status, data.Visibility, data.OSPattern, data.CreatedAfter, data.DeprecationGrace) {
+		// Select the best matching image using the contract-go library
+		imageID, imageName, checksum, version, err := image.HpcrSelectImage(imagesJSON, spec)
+		if err != nil {
+			return common.Image{}, err
+		}
+		return common.Image{ID: imageID, Name: imageName, Sha256: checksum, Version: version}, nil
+	}
+
+	// Filter and select the image in the provider
+	images, err := filterImages(imagesJSON, spec, data.Architecture, data.Status, data.Visibility, data.OSPattern, data.CreatedAfter, data.DeprecationGrace)
+	if err != nil {
+		return common.Image{}, err
+	}
+	if len(images) == 0 {
+		return common.Image{}, fmt.Errorf("no HPCR image matches the configured filters")
+	}
+	return images[0], nil
+}
+
+// lockedImage returns the image recorded in the lock entry from the JSON
+// formatted list of images. The image is looked up by its ID, or by its version
+// for entries without ID, and the image filters are not applied.
+func lockedImage(imagesJSON string, entry common.LockEntry) (common.Image, error) {
+	images, err := common.ParseImages(imagesJSON)
+	if err != nil {
+		return common.Image{}, err
+	}
+
+	for _, img := range images {
+		if entry.Image != "" && img.ID == entry.Image {
+			return img, nil
+		}
+		if entry.Image == "" && img.Version == entry.Version {
+			return img, nil
+		}
+	}
+
+	return common.Image{}, fmt.Errorf("the list of images does not contain the locked image")
+}
+
+// imageFilterConfigured reports whether any of the image filter attributes is set.
+func imageFilterConfigured(architecture, status, visibility, osPattern, createdAfter types.String, deprecationGrace types.Int64) bool {
+	return !architecture.IsNull() || !status.IsNull() || !visibility.IsNull() ||
+		!osPattern.IsNull() || !createdAfter.IsNull() || !deprecationGrace.IsNull()
+}
+
+// filterImages parses the JSON formatted list of images and returns the HPCR
+// images that match the filters and the version range spec, sorted by version
+// in descending order. Filters that are not set default to the candidate
+// checks of contract-go; an empty string disables the check. With a
+// deprecation grace period and no status filter, deprecated images are
+// candidates too, so that the replaced image can still be selected.
+func filterImages(imagesJSON, spec string, architecture, status, visibility, osPattern, createdAfter types.String, deprecationGrace types.Int64) ([]common.Image, error) {
+	filter := common.ImageFilter{
+		Architecture: filterValue(architecture, common.DefaultArchitecture),
+		Visibility:   filterValue(visibility, common.DefaultImageVisibility),
+		OSPattern:    filterValue(osPattern, common.DefaultImageOSPattern),
+	}
+	switch {
+	case status.IsNull() && !deprecationGrace.IsNull():
+		filter.Statuses = []string{common.DefaultImageStatus, common.DeprecatedImageStatus}
+	case status.IsNull():
+		filter.Statuses = []string{common.DefaultImageStatus}
+	case status.ValueString() != "":
+		filter.Statuses = []string{status.ValueString()}
+	}
+	if value := createdAfter.ValueString(); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("created_after must be in RFC 3339 format: %v", err)
+		}
+		filter.CreatedAfter = parsed
+	}
+
+	images, err := common.ParseImages(imagesJSON)
+	if err != nil {
+		return nil, err
+	}
+	images, err = common.FilterImages(images, filter)
+	if err != nil {
+		return nil, err
+	}
+	images, err = common.SelectImages(images, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	grace := time.Duration(deprecationGrace.ValueInt64()) * 24 * time.Hour
+	return common.ApplyDeprecationGrace(images, grace, time.Now()), nil
+}
+
+// filterValue returns the configured value of a filter attribute or
+// defaultValue if the attribute is not set.
+func filterValue(value types.String, defaultValue string) string {
+	if value.IsNull() {
+		return defaultValue
+	}
+	return value.ValueString()
+}
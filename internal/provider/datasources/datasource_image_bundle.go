@@ -0,0 +1,269 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
+	"github.com/ibm-hyper-protect/contract-go/v2/image"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ datasource.DataSource = &ImageBundleDataSource{}
+
+func NewImageBundleDataSource() datasource.DataSource {
+	return &ImageBundleDataSource{}
+}
+
+type ImageBundleDataSource struct{}
+
+type ImageBundleDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Images          types.String `tfsdk:"images"`
+	Spec            types.String `tfsdk:"spec"`
+	Certs           types.Map    `tfsdk:"certs"`
+	Template        types.String `tfsdk:"template"`
+	ImageID         types.String `tfsdk:"image_id"`
+	ImageName       types.String `tfsdk:"image_name"`
+	Sha256          types.String `tfsdk:"sha256"`
+	Version         types.String `tfsdk:"version"`
+	Cert            types.String `tfsdk:"cert"`
+	CertFingerprint types.String `tfsdk:"cert_fingerprint"`
+	CertExpiry      types.String `tfsdk:"cert_expiry"`
+	CertStatus      types.String `tfsdk:"cert_status"`
+}
+
+func (d *ImageBundleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image_bundle"
+}
+
+func (d *ImageBundleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Selects an HPCR image based on semantic versioning and returns it together with the encryption certificate of exactly the same version.",
+		Description:         "Selects an HPCR image and the encryption certificate of the same version.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier",
+			},
+			"images": schema.StringAttribute{
+				MarkdownDescription: "List of images in JSON format",
+				Description:         "List of images in JSON format",
+				Required:            true,
+			},
+			"spec": schema.StringAttribute{
+				MarkdownDescription: "Semantic version range defining the HPCR image. Defaults to '*' (latest).",
+				Description:         "Semantic version range defining the HPCR image",
+				Optional:            true,
+			},
+			"certs": schema.MapAttribute{
+				MarkdownDescription: "Map of certificates from version to certificate content, e.g. the `certs` attribute of `hpcr_encryption_certs`. " +
+					"If not set, the certificate for the selected image version is downloaded.",
+				Description: "Map of certificates from version to certificate",
+				Optional:    true,
+				ElementType: types.MapType{
+					ElemType: types.StringType,
+				},
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "Template used to download the encryption certificate when `certs` is not set. " +
+					"May contain placeholders: {{.Major}}, {{.Minor}}, {{.Patch}}.",
+				Description: "Template used to download the encryption certificate",
+				Optional:    true,
+			},
+			"image_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the selected image",
+				Description:         "ID of the selected image",
+				Computed:            true,
+			},
+			"image_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the selected image",
+				Description:         "Name of the selected image",
+				Computed:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA256 checksum of the selected image",
+				Description:         "SHA256 checksum of the selected image",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version number of the selected image and certificate",
+				Description:         "Version number of the selected image and certificate",
+				Computed:            true,
+			},
+			"cert": schema.StringAttribute{
+				MarkdownDescription: "Encryption certificate for the selected image version",
+				Description:         "Encryption certificate for the selected image version",
+				Computed:            true,
+			},
+			"cert_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded SHA256 fingerprint of the encryption certificate",
+				Description:         "SHA256 fingerprint of the encryption certificate",
+				Computed:            true,
+			},
+			"cert_expiry": schema.StringAttribute{
+				MarkdownDescription: "Number of days for certificate to expire",
+				Description:         "Number of expiry days",
+				Computed:            true,
+			},
+			"cert_status": schema.StringAttribute{
+				MarkdownDescription: "Certificate expiry status of encryption certificate",
+				Description:         "Status of encryption certificate",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ImageBundleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImageBundleDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the images JSON data
+	imageJsonData := data.Images.ValueString()
+	if imageJsonData == "" {
+		resp.Diagnostics.AddError(
+			"Empty images data",
+			"The images field must contain valid JSON data",
+		)
+		return
+	}
+
+	// Get the spec value (default to "*" for latest if not provided)
+	spec := "*"
+	if !data.Spec.IsNull() && !data.Spec.IsUnknown() {
+		spec = data.Spec.ValueString()
+	}
+
+	// Select the best matching image using the contract-go library
+	imageID, imageName, checksum, version, err := image.HpcrSelectImage(imageJsonData, spec)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to select image",
+			fmt.Sprintf("Error selecting HPCR image with spec '%s': %s", spec, err.Error()),
+		)
+		return
+	}
+
+	// Look up the certificate of the image version, or download it
+	certsMap := make(map[string]map[string]string)
+	if !data.Certs.IsNull() && !data.Certs.IsUnknown() {
+		resp.Diagnostics.Append(data.Certs.ElementsAs(ctx, &certsMap, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if _, ok := certsMap[version]; !ok {
+			resp.Diagnostics.AddError(
+				"Missing encryption certificate",
+				fmt.Sprintf("The certs map does not contain a certificate for image version '%s'", version),
+			)
+			return
+		}
+	} else {
+		template := ""
+		if !data.Template.IsNull() && !data.Template.IsUnknown() {
+			template = data.Template.ValueString()
+		}
+
+		certsJSON, err := certificate.HpcrDownloadEncryptionCertificates([]string{version}, "json", template)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to download encryption certificate",
+				fmt.Sprintf("Error downloading certificate for image version '%s': %s", version, err.Error()),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Certificates JSON - %s", certsJSON))
+
+		if err := json.Unmarshal([]byte(certsJSON), &certsMap); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to parse certificates JSON",
+				fmt.Sprintf("Error parsing JSON response: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	// Convert certs map to JSON for the library function
+	certsJSON, err := json.Marshal(certsMap)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to marshal certificates",
+			fmt.Sprintf("Error converting certificates to JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	// Get the certificate using the contract-go library
+	certVersion, cert, _, expiryDays, status, err := certificate.HpcrGetEncryptionCertificateFromJson(string(certsJSON), version)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get encryption certificate",
+			fmt.Sprintf("Error retrieving certificate for image version '%s': %s", version, err.Error()),
+		)
+		return
+	}
+	if certVersion != version {
+		resp.Diagnostics.AddError(
+			"Encryption certificate version mismatch",
+			fmt.Sprintf("Selected image has version '%s' but the certificate has version '%s'", version, certVersion),
+		)
+		return
+	}
+
+	certs, err := common.ParseCertificates(cert)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to parse encryption certificate",
+			fmt.Sprintf("Error parsing certificate for image version '%s': %s", version, err.Error()),
+		)
+		return
+	}
+
+	fingerprint := common.CertificateFingerprint(certs[0])
+
+	// Derive the data source ID from the spec and the selected image and certificate
+	id := common.StableID(spec, imageID, version, fingerprint)
+
+	// Set the computed fields
+	data.ImageID = types.StringValue(imageID)
+	data.ImageName = types.StringValue(imageName)
+	data.Sha256 = types.StringValue(checksum)
+	data.Version = types.StringValue(version)
+	data.Cert = types.StringValue(cert)
+	data.CertFingerprint = types.StringValue(fingerprint)
+	data.CertExpiry = types.StringValue(expiryDays)
+	data.CertStatus = types.StringValue(status)
+	data.ID = types.StringValue(id)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestImageBundleDataSource_Metadata(t *testing.T) {
+	ds := NewImageBundleDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_image_bundle" {
+		t.Errorf("Expected TypeName to be 'hpcr_image_bundle', got '%s'", resp.TypeName)
+	}
+}
+
+func TestImageBundleDataSource_Schema(t *testing.T) {
+	ds := NewImageBundleDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has required attributes
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	requiredAttrs := []string{"id", "images", "spec", "certs", "template", "image_id", "image_name", "sha256", "version", "cert", "cert_fingerprint", "cert_expiry", "cert_status"}
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected schema to have attribute '%s'", attr)
+		}
+	}
+
+	// Verify images is required
+	if resp.Schema.Attributes["images"].IsRequired() == false {
+		t.Error("Expected 'images' attribute to be required")
+	}
+
+	// Verify optional attributes
+	for _, attr := range []string{"spec", "certs", "template"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
+	// Verify computed attributes
+	for _, attr := range []string{"id", "image_id", "image_name", "sha256", "version", "cert", "cert_fingerprint", "cert_expiry", "cert_status"} {
+		if resp.Schema.Attributes[attr].IsComputed() == false {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+}
+
+func TestNewImageBundleDataSource(t *testing.T) {
+	ds := NewImageBundleDataSource()
+	if ds == nil {
+		t.Fatal("NewImageBundleDataSource should not return nil")
+	}
+
+	// Verify it implements the DataSource interface
+	var _ datasource.DataSource = &ImageBundleDataSource{}
+}
+
+func TestImageBundleDataSource_SchemaDescriptions(t *testing.T) {
+	ds := NewImageBundleDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has descriptions
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	// Verify attributes have descriptions (either Description or MarkdownDescription)
+	for name, attr := range resp.Schema.Attributes {
+		desc := attr.GetDescription()
+		mdDesc := attr.GetMarkdownDescription()
+		if desc == "" && mdDesc == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
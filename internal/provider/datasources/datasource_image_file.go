@@ -0,0 +1,281 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ datasource.DataSource = &ImageFileDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ImageFileDataSource{}
+
+func NewImageFileDataSource() datasource.DataSource {
+	return &ImageFileDataSource{}
+}
+
+type ImageFileDataSource struct{}
+
+type ImageFileDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Path              types.String `tfsdk:"path"`
+	ChecksumFile      types.String `tfsdk:"checksum_file"`
+	ChecksumSignature types.String `tfsdk:"checksum_signature"`
+	Cert              types.String `tfsdk:"cert"`
+	TrustBundle       types.String `tfsdk:"trust_bundle"`
+	Manifest          types.String `tfsdk:"manifest"`
+	Sha256            types.String `tfsdk:"sha256"`
+	Size              types.Int64  `tfsdk:"size"`
+	Version           types.String `tfsdk:"version"`
+	Verified          types.Bool   `tfsdk:"verified"`
+}
+
+func (d *ImageFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image_file"
+}
+
+func (d *ImageFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Computes the SHA256 checksum of a local HPCR image file, e.g. a qcow2 image for on-prem KVM deployments, and verifies it against a signed checksum file.",
+		Description:         "Computes and verifies the checksum of a local HPCR image file.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier",
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the local image file",
+				Description:         "Path of the local image file",
+				Required:            true,
+			},
+			"checksum_file": schema.StringAttribute{
+				MarkdownDescription: "Content of the checksum file in `sha256sum` or BSD format. The checksum listed for the file name of `path` must match the image file.",
+				Description:         "Content of the checksum file in sha256sum or BSD format",
+				Optional:            true,
+			},
+			"checksum_signature": schema.StringAttribute{
+				MarkdownDescription: "Base64-encoded signature of the checksum file (use `filebase64()` in Terraform). Must be provided together with `cert` and `checksum_file`.",
+				Description:         "Base64-encoded signature of the checksum file",
+				Optional:            true,
+			},
+			"cert": schema.StringAttribute{
+				MarkdownDescription: "IBM signing certificate used to validate `checksum_signature`, in PEM format",
+				Description:         "IBM signing certificate used to validate the checksum signature, in PEM format",
+				Optional:            true,
+			},
+			"trust_bundle": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates trusted to issue `cert`. When set, the chain and validity period of `cert` are validated before the signature is verified. Intermediate certificates can be appended to `cert` after the signing certificate. Requires `cert`.",
+				Description:         "PEM encoded CA certificates trusted to issue the signing certificate",
+				Optional:            true,
+			},
+			"manifest": schema.StringAttribute{
+				MarkdownDescription: "JSON manifest with a top level `version` field. If not set, the version is parsed from the file name.",
+				Description:         "JSON manifest with a top level version field",
+				Optional:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA256 checksum of the image file",
+				Description:         "SHA256 checksum of the image file",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the image file in bytes",
+				Description:         "Size of the image file in bytes",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version number of the image, from `manifest` or the file name",
+				Description:         "Version number of the image",
+				Computed:            true,
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the checksum was verified against a checksum file with a valid signature. Without `trust_bundle`, this only means that the checksum file was signed by `cert`, which is not validated.",
+				Description:         "Whether the checksum was verified against a signed checksum file",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ImageFileDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data ImageFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Cert.IsUnknown() || data.ChecksumSignature.IsUnknown() || data.ChecksumFile.IsUnknown() || data.TrustBundle.IsUnknown() {
+		return
+	}
+
+	if data.Cert.IsNull() != data.ChecksumSignature.IsNull() {
+		resp.Diagnostics.AddError(
+			"Incomplete signature configuration",
+			"'cert' and 'checksum_signature' must be provided together",
+		)
+	}
+	if !data.ChecksumSignature.IsNull() && data.ChecksumFile.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing checksum file",
+			"'checksum_signature' requires 'checksum_file'",
+		)
+	}
+	if !data.TrustBundle.IsNull() && data.Cert.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing signing certificate",
+			"'trust_bundle' requires 'cert'",
+		)
+	}
+}
+
+func (d *ImageFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImageFileDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	path := data.Path.ValueString()
+	fileName := filepath.Base(path)
+
+	// Determine the image version
+	var version string
+	var err error
+	if manifest := data.Manifest.ValueString(); manifest != "" {
+		version, err = common.ImageVersionFromManifest(manifest)
+	} else {
+		version, err = common.ImageVersionFromFileName(path)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to determine image version",
+			err.Error(),
+		)
+		return
+	}
+
+	// Verify the signature of the checksum file before trusting its content
+	verified := false
+	if cert := data.Cert.ValueString(); cert != "" {
+		// Validate the signing certificate before it is used to verify the signature
+		if trustBundle := data.TrustBundle.ValueString(); trustBundle != "" {
+			if _, err := common.VerifyCertificateChain(cert, trustBundle, nil, time.Now()); err != nil {
+				resp.Diagnostics.AddError(
+					"Signing certificate validation failed",
+					fmt.Sprintf("Error validating signing certificate: %s", err.Error()),
+				)
+				return
+			}
+		} else {
+			resp.Diagnostics.AddWarning(
+				"Signing certificate not validated",
+				"No 'trust_bundle' was provided, so the signing certificate is not validated against a trusted CA. Any certificate, including a self-signed one, is accepted.",
+			)
+		}
+
+		sigBytes, err := base64.StdEncoding.DecodeString(data.ChecksumSignature.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid checksum signature",
+				fmt.Sprintf("'checksum_signature' must be base64-encoded (use filebase64() in Terraform): %s", err.Error()),
+			)
+			return
+		}
+		if err := common.VerifySignature([]byte(data.ChecksumFile.ValueString()), sigBytes, cert); err != nil {
+			resp.Diagnostics.AddError(
+				"Checksum file signature verification failed",
+				err.Error(),
+			)
+			return
+		}
+		tflog.Debug(ctx, "Checksum file signature verification successful")
+		verified = true
+	}
+
+	var expected string
+	if checksumFile := data.ChecksumFile.ValueString(); checksumFile != "" {
+		checksums, err := common.ParseChecksumFile(checksumFile)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to parse checksum file",
+				err.Error(),
+			)
+			return
+		}
+		var ok bool
+		if expected, ok = checksums[fileName]; !ok {
+			resp.Diagnostics.AddError(
+				"Missing checksum",
+				fmt.Sprintf("The checksum file does not contain a checksum for '%s'", fileName),
+			)
+			return
+		}
+	}
+
+	// Hash the image file, logging the progress in steps of 10 percent
+	lastStep := int64(-1)
+	checksum, size, err := common.HashFile(path, func(done, total int64) {
+		if total == 0 {
+			return
+		}
+		if step := done * 10 / total; step != lastStep {
+			lastStep = step
+			tflog.Info(ctx, fmt.Sprintf("Hashing %s: %d%% (%d of %d bytes)", fileName, step*10, done, total))
+		}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to hash image file",
+			err.Error(),
+		)
+		return
+	}
+
+	if expected != "" && checksum != expected {
+		resp.Diagnostics.AddError(
+			"Image checksum mismatch",
+			fmt.Sprintf("SHA256 of '%s' is %s, but the checksum file lists %s", fileName, checksum, expected),
+		)
+		return
+	}
+
+	// Derive the data source ID from the path and the checksum of the image file
+	id := common.StableID(path, checksum)
+
+	// Set the computed fields
+	data.Sha256 = types.StringValue(checksum)
+	data.Size = types.Int64Value(size)
+	data.Version = types.StringValue(version)
+	data.Verified = types.BoolValue(verified)
+	data.ID = types.StringValue(id)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
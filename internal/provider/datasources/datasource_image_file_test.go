@@ -0,0 +1,224 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImageFileDataSource_Metadata(t *testing.T) {
+	ds := NewImageFileDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_image_file" {
+		t.Errorf("Expected TypeName to be 'hpcr_image_file', got '%s'", resp.TypeName)
+	}
+}
+
+func TestImageFileDataSource_Schema(t *testing.T) {
+	ds := NewImageFileDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has required attributes
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	requiredAttrs := []string{"id", "path", "checksum_file", "checksum_signature", "cert", "trust_bundle", "manifest", "sha256", "size", "version", "verified"}
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected schema to have attribute '%s'", attr)
+		}
+	}
+
+	// Verify path is required
+	if resp.Schema.Attributes["path"].IsRequired() == false {
+		t.Error("Expected 'path' attribute to be required")
+	}
+
+	// Verify optional attributes
+	for _, attr := range []string{"checksum_file", "checksum_signature", "cert", "trust_bundle", "manifest"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
+	// Verify computed attributes
+	for _, attr := range []string{"id", "sha256", "size", "version", "verified"} {
+		if resp.Schema.Attributes[attr].IsComputed() == false {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+}
+
+func TestNewImageFileDataSource(t *testing.T) {
+	ds := NewImageFileDataSource()
+	if ds == nil {
+		t.Fatal("NewImageFileDataSource should not return nil")
+	}
+
+	// Verify it implements the DataSource interface
+	var _ datasource.DataSource = &ImageFileDataSource{}
+	var _ datasource.DataSourceWithValidateConfig = &ImageFileDataSource{}
+}
+
+func TestImageFileDataSource_SchemaDescriptions(t *testing.T) {
+	ds := NewImageFileDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has descriptions
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	// Verify attributes have descriptions (either Description or MarkdownDescription)
+	for name, attr := range resp.Schema.Attributes {
+		desc := attr.GetDescription()
+		mdDesc := attr.GetMarkdownDescription()
+		if desc == "" && mdDesc == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
+
+// newTestSigningCert creates a certificate and its key, issued by parent or
+// self-signed if parent is nil.
+func newTestSigningCert(t *testing.T, subject string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: subject},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	return cert, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestImageFileDataSource_ReadTrustBundle(t *testing.T) {
+	root, rootKey, rootPEM := newTestSigningCert(t, "IBM Root", nil, nil)
+	_, signerKey, signerPEM := newTestSigningCert(t, "HPCR Image Signing", root, rootKey)
+	_, _, otherRootPEM := newTestSigningCert(t, "Other Root", nil, nil)
+
+	imagePath := filepath.Join(t.TempDir(), "ibm-hyper-protect-container-runtime-1-0-s390x-20.qcow2")
+	if err := os.WriteFile(imagePath, []byte("qcow2 image"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	checksum := sha256.Sum256([]byte("qcow2 image"))
+	checksumFile := fmt.Sprintf("%x  %s\n", checksum, filepath.Base(imagePath))
+	digest := sha256.Sum256([]byte(checksumFile))
+	signature, err := ecdsa.SignASN1(rand.Reader, signerKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		trustBundle   string
+		expectWarning bool
+		expectedError string
+	}{
+		{"trusted", rootPEM, false, ""},
+		{"untrusted", otherRootPEM, false, "Signing certificate validation failed"},
+		{"no trust bundle", "", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]tftypes.Value{
+				"path":               tftypes.NewValue(tftypes.String, imagePath),
+				"checksum_file":      tftypes.NewValue(tftypes.String, checksumFile),
+				"checksum_signature": tftypes.NewValue(tftypes.String, base64.StdEncoding.EncodeToString(signature)),
+				"cert":               tftypes.NewValue(tftypes.String, signerPEM),
+			}
+			if tt.trustBundle != "" {
+				values["trust_bundle"] = tftypes.NewValue(tftypes.String, tt.trustBundle)
+			}
+
+			var data ImageFileDataSourceModel
+			diags := testReadDiagnostics(t, NewImageFileDataSource(), values, &data)
+			if tt.expectedError != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.expectedError {
+					t.Fatalf("Expected error %q, got %v", tt.expectedError, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Read failed: %v", diags)
+			}
+			if !data.Verified.ValueBool() {
+				t.Error("Expected the checksum to be verified")
+			}
+			if (diags.WarningsCount() > 0) != tt.expectWarning {
+				t.Errorf("Expected warning %t, got %v", tt.expectWarning, diags)
+			}
+		})
+	}
+}
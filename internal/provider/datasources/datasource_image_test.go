@@ -16,9 +16,17 @@ package datasources
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
 )
 
 func TestImageDataSource_Metadata(t *testing.T) {
@@ -68,6 +76,14 @@ func TestImageDataSource_Schema(t *testing.T) {
 		t.Error("Expected 'spec' attribute to be optional")
 	}
 
+	// Verify filter and lock attributes are optional
+	filterAttrs := []string{"architecture", "status", "visibility", "os_pattern", "created_after", "deprecation_grace", "lock_file", "lock_key", "lock_generation"}
+	for _, attr := range filterAttrs {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
 	// Verify computed attributes
 	computedAttrs := []string{"id", "image", "name", "version", "sha256"}
 	for _, attr := range computedAttrs {
@@ -113,3 +129,167 @@ func TestImageDataSource_SchemaDescriptions(t *testing.T) {
 		}
 	}
 }
+
+func TestImageDataSource_FilterImages(t *testing.T) {
+	imagesJSON := `[
+		{"id": "r006-old", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-20", "status": "available", "visibility": "public", "created_at": "2025-01-01T00:00:00Z", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "aaa"}}},
+		{"id": "r006-new", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-21", "status": "deprecated", "visibility": "public", "created_at": "2025-06-01T00:00:00Z", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "bbb"}}}
+	]`
+
+	null := types.StringNull()
+	images, err := filterImages(imagesJSON, "*", null, types.StringValue("available"), null, null, null, types.Int64Null())
+	if err != nil {
+		t.Fatalf("filterImages failed: %v", err)
+	}
+	if len(images) != 1 || images[0].ID != "r006-old" {
+		t.Errorf("Expected only the available image to be selected, got %v", images)
+	}
+
+	if _, err := filterImages(imagesJSON, "*", null, null, null, null, types.StringValue("yesterday"), types.Int64Null()); err == nil {
+		t.Error("Expected an error for a created_after value that is not in RFC 3339 format")
+	}
+
+	images, err = filterImages(imagesJSON, "*", types.StringValue("s390x"), null, null, null, null, types.Int64Null())
+	if err != nil {
+		t.Fatalf("filterImages failed: %v", err)
+	}
+	if len(images) != 1 || images[0].ID != "r006-old" {
+		t.Errorf("Expected the default status filter to skip the deprecated image, got %v", images)
+	}
+
+	images, err = filterImages(imagesJSON, "*", null, null, null, null, null, types.Int64Value(0))
+	if err != nil {
+		t.Fatalf("filterImages failed: %v", err)
+	}
+	if len(images) != 2 || images[0].ID != "r006-new" {
+		t.Errorf("Expected a deprecation grace period to admit deprecated images, got %v", images)
+	}
+
+	images, err = filterImages(imagesJSON, "*", null, types.StringValue(""), null, null, null, types.Int64Null())
+	if err != nil {
+		t.Fatalf("filterImages failed: %v", err)
+	}
+	if len(images) != 2 || images[0].ID != "r006-new" {
+		t.Errorf("Expected an empty status to select images of any status, got %v", images)
+	}
+}
+
+func TestImageDataSource_FilterValidators(t *testing.T) {
+	ds := NewImageDataSource()
+	resp := &datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	if attr := resp.Schema.Attributes["created_after"].(schema.StringAttribute); len(attr.Validators) == 0 {
+		t.Error("Expected created_after to be validated at plan time")
+	}
+	if attr := resp.Schema.Attributes["deprecation_grace"].(schema.Int64Attribute); len(attr.Validators) == 0 {
+		t.Error("Expected deprecation_grace to be validated at plan time")
+	}
+}
+
+func TestImageFilterConfigured(t *testing.T) {
+	null := types.StringNull()
+	if imageFilterConfigured(null, null, null, null, null, types.Int64Null()) {
+		t.Error("Expected no filter to be configured")
+	}
+	if !imageFilterConfigured(null, null, null, null, null, types.Int64Value(7)) {
+		t.Error("Expected deprecation_grace to configure a filter")
+	}
+}
+
+func TestImageDataSource_ReadDeprecationGraceKeepsOnlyMatch(t *testing.T) {
+	imagesJSON := fmt.Sprintf(`[
+		{"id": "r006-new", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-21", "status": "available", "visibility": "public", "created_at": %q, "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "bbb"}}}
+	]`, time.Now().UTC().Format(time.RFC3339))
+
+	var data ImageDataSourceModel
+	testRead(t, NewImageDataSource(), map[string]tftypes.Value{
+		"images":            tftypes.NewValue(tftypes.String, imagesJSON),
+		"spec":              tftypes.NewValue(tftypes.String, "1.0.21"),
+		"deprecation_grace": tftypes.NewValue(tftypes.Number, 30),
+	}, &data)
+
+	if data.ImageID.ValueString() != "r006-new" {
+		t.Errorf("Expected the only matching image to be selected within the grace period, got %s", data.ImageID.ValueString())
+	}
+}
+
+func TestImageDataSource_ReadDeprecationGraceKeepsDeprecatedPredecessor(t *testing.T) {
+	now := time.Now().UTC()
+	imagesJSON := fmt.Sprintf(`[
+		{"id": "r006-old", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-20", "status": "deprecated", "visibility": "public", "created_at": %q, "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "aaa"}}},
+		{"id": "r006-new", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-21", "status": "available", "visibility": "public", "created_at": %q, "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "bbb"}}}
+	]`, now.AddDate(0, 0, -60).Format(time.RFC3339), now.AddDate(0, 0, -3).Format(time.RFC3339))
+
+	tests := []struct {
+		name          string
+		status        tftypes.Value
+		expectedImage string
+	}{
+		{"status not set", tftypes.NewValue(tftypes.String, nil), "r006-old"},
+		{"status available", tftypes.NewValue(tftypes.String, "available"), "r006-new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data ImageDataSourceModel
+			testRead(t, NewImageDataSource(), map[string]tftypes.Value{
+				"images":            tftypes.NewValue(tftypes.String, imagesJSON),
+				"status":            tt.status,
+				"deprecation_grace": tftypes.NewValue(tftypes.Number, 14),
+			}, &data)
+
+			if data.ImageID.ValueString() != tt.expectedImage {
+				t.Errorf("Expected image %s, got %s", tt.expectedImage, data.ImageID.ValueString())
+			}
+		})
+	}
+}
+
+func TestImageDataSource_ReadLock(t *testing.T) {
+	imagesJSON := `[
+		{"id": "r006-old", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-20", "status": "deprecated", "visibility": "public", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "aaa"}}},
+		{"id": "r006-new", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-21", "status": "available", "visibility": "public", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "bbb"}}}
+	]`
+
+	tests := []struct {
+		name          string
+		entry         common.LockEntry
+		expectedImage string
+		expectedError string
+	}{
+		{"reuse deprecated image", common.LockEntry{Spec: "*", Version: "1.0.20", Image: "r006-old", Sha256: "aaa"}, "r006-old", ""},
+		{"reuse by version", common.LockEntry{Spec: "*", Version: "1.0.20"}, "r006-old", ""},
+		{"checksum mismatch", common.LockEntry{Spec: "*", Version: "1.0.20", Image: "r006-old", Sha256: "zzz"}, "", "Locked image changed"},
+		{"image removed", common.LockEntry{Spec: "*", Version: "1.0.19", Image: "r006-gone", Sha256: "ccc"}, "", "Locked image not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockFile := filepath.Join(t.TempDir(), "hpcr.lock.json")
+			if err := common.RecordLock(lockFile, "image:*", tt.entry); err != nil {
+				t.Fatalf("RecordLock failed: %v", err)
+			}
+
+			var data ImageDataSourceModel
+			diags := testReadDiagnostics(t, NewImageDataSource(), map[string]tftypes.Value{
+				"images":    tftypes.NewValue(tftypes.String, imagesJSON),
+				"status":    tftypes.NewValue(tftypes.String, "available"),
+				"lock_file": tftypes.NewValue(tftypes.String, lockFile),
+			}, &data)
+
+			if tt.expectedError != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.expectedError {
+					t.Fatalf("Expected error %q, got %v", tt.expectedError, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Read failed: %v", diags)
+			}
+			if data.ImageID.ValueString() != tt.expectedImage {
+				t.Errorf("Expected locked image %s, got %s", tt.expectedImage, data.ImageID.ValueString())
+			}
+		})
+	}
+}
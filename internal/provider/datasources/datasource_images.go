@@ -0,0 +1,249 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
+)
+
+var _ datasource.DataSource = &ImagesDataSource{}
+
+func NewImagesDataSource() datasource.DataSource {
+	return &ImagesDataSource{}
+}
+
+type ImagesDataSource struct{}
+
+type ImagesDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Images           types.String `tfsdk:"images"`
+	Spec             types.String `tfsdk:"spec"`
+	Limit            types.Int64  `tfsdk:"limit"`
+	Architecture     types.String `tfsdk:"architecture"`
+	Status           types.String `tfsdk:"status"`
+	Visibility       types.String `tfsdk:"visibility"`
+	OSPattern        types.String `tfsdk:"os_pattern"`
+	CreatedAfter     types.String `tfsdk:"created_after"`
+	DeprecationGrace types.Int64  `tfsdk:"deprecation_grace"`
+	Matches          types.List   `tfsdk:"matches"`
+}
+
+type imageMatchModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+	Sha256  types.String `tfsdk:"sha256"`
+	Status  types.String `tfsdk:"status"`
+}
+
+var imageMatchAttrTypes = map[string]attr.Type{
+	"id":      types.StringType,
+	"name":    types.StringType,
+	"version": types.StringType,
+	"sha256":  types.StringType,
+	"status":  types.StringType,
+}
+
+func (d *ImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_images"
+}
+
+func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all HPCR images from a JSON formatted list of images that match a semantic version range, sorted by version in descending order. Without filters, only available public Hyper Protect images are listed, like the candidates of `hpcr_image`.",
+		Description:         "Lists all HPCR images from a JSON formatted list of images that match a semantic version range.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier",
+			},
+			"images": schema.StringAttribute{
+				MarkdownDescription: "List of images in JSON format",
+				Description:         "List of images in JSON format",
+				Required:            true,
+			},
+			"spec": schema.StringAttribute{
+				MarkdownDescription: "Semantic version range defining the HPCR images. Defaults to '*' (all versions).",
+				Description:         "Semantic version range defining the HPCR images",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of images to return. Defaults to all matching images.",
+				Description:         "Maximum number of images to return",
+				Optional:            true,
+			},
+			"architecture": schema.StringAttribute{
+				MarkdownDescription: "Only select images with this architecture. Defaults to `s390x`, an empty string disables the check.",
+				Description:         "Only select images with this architecture",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only select images with this status, e.g. `available` or `deprecated`. Defaults to `available`, or `available` and `deprecated` with `deprecation_grace`. An empty string disables the check.",
+				Description:         "Only select images with this status",
+				Optional:            true,
+			},
+			"visibility": schema.StringAttribute{
+				MarkdownDescription: "Only select images with this visibility, e.g. `public` or `private`. Defaults to `public`, an empty string disables the check.",
+				Description:         "Only select images with this visibility",
+				Optional:            true,
+			},
+			"os_pattern": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the operating system name of the image must match. Defaults to `^hyper-protect-[\\w-]+-s390x-hpcr$`, an empty string disables the check.",
+				Description:         "Regular expression the operating system name of the image must match",
+				Optional:            true,
+			},
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: "Only select images created at or after this date, in RFC 3339 format (e.g. `2025-01-01T00:00:00Z`)",
+				Description:         "Only select images created at or after this date, in RFC 3339 format",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.RFC3339(),
+				},
+			},
+			"deprecation_grace": schema.Int64Attribute{
+				MarkdownDescription: "Number of days a newly released image is ignored",
+				Description:         "Number of days a newly released image is ignored",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"matches": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching images, sorted by version in descending order",
+				Description:         "Matching images, sorted by version in descending order",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the image",
+							Description:         "ID of the image",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the image",
+							Description:         "Name of the image",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "Version number of the image",
+							Description:         "Version number of the image",
+							Computed:            true,
+						},
+						"sha256": schema.StringAttribute{
+							MarkdownDescription: "SHA256 checksum of the image",
+							Description:         "SHA256 checksum of the image",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Status of the image, e.g. `available` or `deprecated`",
+							Description:         "Status of the image",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImagesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the images JSON data
+	imageJsonData := data.Images.ValueString()
+	if imageJsonData == "" {
+		resp.Diagnostics.AddError(
+			"Empty images data",
+			"The images field must contain valid JSON data",
+		)
+		return
+	}
+
+	// Get the spec value (default to "*" for all versions if not provided)
+	spec := "*"
+	if !data.Spec.IsNull() && !data.Spec.IsUnknown() {
+		spec = data.Spec.ValueString()
+	}
+
+	if data.Limit.ValueInt64() < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid limit",
+			"The limit field must not be negative",
+		)
+		return
+	}
+
+	images, err := filterImages(imageJsonData, spec, data.Architecture, data.Status, data.Visibility, data.OSPattern, data.CreatedAfter, data.DeprecationGrace)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to select images",
+			fmt.Sprintf("Error selecting HPCR images with spec '%s': %s", spec, err.Error()),
+		)
+		return
+	}
+	if limit := int(data.Limit.ValueInt64()); limit > 0 && len(images) > limit {
+		images = images[:limit]
+	}
+
+	matchValues := make([]imageMatchModel, 0, len(images))
+	for _, img := range images {
+		matchValues = append(matchValues, imageMatchModel{
+			ID:      types.StringValue(img.ID),
+			Name:    types.StringValue(img.Name),
+			Version: types.StringValue(img.Version),
+			Sha256:  types.StringValue(img.Sha256),
+			Status:  types.StringValue(img.Status),
+		})
+	}
+	matches, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: imageMatchAttrTypes}, matchValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matchIDs := make([]string, 0, len(images))
+	for _, img := range images {
+		matchIDs = append(matchIDs, img.ID)
+	}
+
+	// Derive the data source ID from the spec and the matching images
+	id := common.StableID(append([]string{spec}, matchIDs...)...)
+
+	// Set the computed fields
+	data.Matches = matches
+	data.ID = types.StringValue(id)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
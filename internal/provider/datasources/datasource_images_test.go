@@ -0,0 +1,148 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImagesDataSource_Metadata(t *testing.T) {
+	ds := NewImagesDataSource()
+
+	req := datasource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &datasource.MetadataResponse{}
+
+	ds.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_images" {
+		t.Errorf("Expected TypeName to be 'hpcr_images', got '%s'", resp.TypeName)
+	}
+}
+
+func TestImagesDataSource_Schema(t *testing.T) {
+	ds := NewImagesDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has required attributes
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	requiredAttrs := []string{"id", "images", "spec", "limit", "architecture", "status", "visibility", "os_pattern", "created_after", "deprecation_grace", "matches"}
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected schema to have attribute '%s'", attr)
+		}
+	}
+
+	// Verify images is required
+	if resp.Schema.Attributes["images"].IsRequired() == false {
+		t.Error("Expected 'images' attribute to be required")
+	}
+
+	// Verify spec and limit are optional
+	for _, attr := range []string{"spec", "limit"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
+	// Verify computed attributes
+	for _, attr := range []string{"id", "matches"} {
+		if resp.Schema.Attributes[attr].IsComputed() == false {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+}
+
+func TestNewImagesDataSource(t *testing.T) {
+	ds := NewImagesDataSource()
+	if ds == nil {
+		t.Fatal("NewImagesDataSource should not return nil")
+	}
+
+	// Verify it implements the DataSource interface
+	var _ datasource.DataSource = &ImagesDataSource{}
+}
+
+func TestImagesDataSource_SchemaDescriptions(t *testing.T) {
+	ds := NewImagesDataSource()
+
+	req := datasource.SchemaRequest{}
+	resp := &datasource.SchemaResponse{}
+
+	ds.Schema(context.TODO(), req, resp)
+
+	// Verify schema has descriptions
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	// Verify attributes have descriptions (either Description or MarkdownDescription)
+	for name, attr := range resp.Schema.Attributes {
+		desc := attr.GetDescription()
+		mdDesc := attr.GetMarkdownDescription()
+		if desc == "" && mdDesc == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
+
+func TestImagesDataSource_ReadDefaultFilters(t *testing.T) {
+	imagesJSON := `[
+		{"id": "r006-20", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-20", "status": "available", "visibility": "public", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "aaa"}}},
+		{"id": "r006-21", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-21", "status": "available", "visibility": "public", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "bbb"}}},
+		{"id": "r006-22", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-22", "status": "deprecated", "visibility": "public", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "ccc"}}},
+		{"id": "r006-23", "name": "ibm-hyper-protect-container-runtime-1-0-s390x-23", "status": "available", "visibility": "private", "operating_system": {"name": "hyper-protect-1-0-s390x-hpcr", "architecture": "s390x"}, "file": {"checksums": {"sha256": "ddd"}}}
+	]`
+
+	var data ImagesDataSourceModel
+	testRead(t, NewImagesDataSource(), map[string]tftypes.Value{
+		"images": tftypes.NewValue(tftypes.String, imagesJSON),
+	}, &data)
+
+	var matches []imageMatchModel
+	if diags := data.Matches.ElementsAs(context.Background(), &matches, false); diags.HasError() {
+		t.Fatalf("Failed to read matches: %v", diags)
+	}
+	if len(matches) != 2 || matches[0].ID.ValueString() != "r006-21" || matches[1].ID.ValueString() != "r006-20" {
+		t.Errorf("Expected only the available public images, newest first, got %v", matches)
+	}
+
+	testRead(t, NewImagesDataSource(), map[string]tftypes.Value{
+		"images": tftypes.NewValue(tftypes.String, imagesJSON),
+		"status": tftypes.NewValue(tftypes.String, ""),
+	}, &data)
+	if diags := data.Matches.ElementsAs(context.Background(), &matches, false); diags.HasError() {
+		t.Fatalf("Failed to read matches: %v", diags)
+	}
+	if len(matches) != 3 || matches[0].ID.ValueString() != "r006-22" {
+		t.Errorf("Expected an empty status to also list deprecated images, got %v", matches)
+	}
+}
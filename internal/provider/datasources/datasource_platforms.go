@@ -0,0 +1,128 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ datasource.DataSource = &PlatformsDataSource{}
+
+func NewPlatformsDataSource() datasource.DataSource {
+	return &PlatformsDataSource{}
+}
+
+type PlatformsDataSource struct{}
+
+type PlatformsDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	DefaultPlatform types.String `tfsdk:"default_platform"`
+	Platforms       types.List   `tfsdk:"platforms"`
+}
+
+type platformModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+var platformAttrTypes = map[string]attr.Type{
+	"name":        types.StringType,
+	"description": types.StringType,
+}
+
+func (d *PlatformsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_platforms"
+}
+
+func (d *PlatformsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Hyper Protect platforms accepted by the `platform` attribute of the encryption resources.",
+		Description:         "Lists the supported Hyper Protect platforms.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier",
+			},
+			"default_platform": schema.StringAttribute{
+				MarkdownDescription: "Platform used when `platform` is not set",
+				Description:         "Platform used when platform is not set",
+				Computed:            true,
+			},
+			"platforms": schema.ListNestedAttribute{
+				MarkdownDescription: "Supported platforms",
+				Description:         "Supported platforms",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Value of the `platform` attribute",
+							Description:         "Value of the platform attribute",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Name of the product",
+							Description:         "Name of the product",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PlatformsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PlatformsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	platforms := common.Platforms()
+	platformValues := make([]platformModel, 0, len(platforms))
+	for _, platform := range platforms {
+		platformValues = append(platformValues, platformModel{
+			Name:        types.StringValue(platform.Name),
+			Description: types.StringValue(platform.Description),
+		})
+	}
+	platformList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: platformAttrTypes}, platformValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The ID only changes with the platform matrix of the provider
+	id := common.StableID(common.PlatformNames()...)
+
+	// Set the computed fields
+	data.DefaultPlatform = types.StringValue(common.DefaultPlatform)
+	data.Platforms = platformList
+	data.ID = types.StringValue(id)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
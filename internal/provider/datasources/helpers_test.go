@@ -0,0 +1,68 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testRead runs Read of the data source with a configuration in which the
+// given attributes are set and all others are null. The resulting state is
+// read into result.
+func testRead(t *testing.T, d datasource.DataSource, values map[string]tftypes.Value, result any) {
+	t.Helper()
+
+	diags := testReadDiagnostics(t, d, values, result)
+	if diags.HasError() {
+		t.Fatalf("Read failed: %v", diags)
+	}
+}
+
+// testReadDiagnostics runs Read like testRead and returns its diagnostics. The
+// state is only read into result if Read succeeded.
+func testReadDiagnostics(t *testing.T, d datasource.DataSource, values map[string]tftypes.Value, result any) diag.Diagnostics {
+	t.Helper()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(context.TODO(), datasource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(context.TODO()).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.TODO(), datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		return resp.Diagnostics
+	}
+
+	if diags := resp.State.Get(context.TODO(), result); diags.HasError() {
+		t.Fatalf("Failed to read state: %v", diags)
+	}
+	return resp.Diagnostics
+}
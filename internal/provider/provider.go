@@ -16,11 +16,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/datasources"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/resources"
 )
@@ -38,7 +42,7 @@ type HPCRProvider struct {
 
 // HPCRProviderModel describes the provider data model.
 type HPCRProviderModel struct {
-	// Add provider-level configuration fields here if needed in the future
+	IDMode types.String `tfsdk:"id_mode"`
 }
 
 func (p *HPCRProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -52,6 +56,14 @@ func (p *HPCRProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 			"This provider helps create encrypted contracts and user data for secure virtual servers.",
 		MarkdownDescription: "Terraform provider for IBM Cloud Hyper Protect Virtual Server for VPC (HPCR). " +
 			"This provider helps create encrypted contracts and user data for secure virtual servers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id_mode": schema.StringAttribute{
+				MarkdownDescription: "Scheme of the `id` of new resources, `random` for a random UUID or `stable` for an ID derived from the inputs of the resource. Defaults to `random`. Data source IDs are always derived from their inputs and results.",
+				Description:         "Scheme of the id of new resources, random or stable",
+				Optional:            true,
+			},
+		},
 	}
 }
 
@@ -64,11 +76,25 @@ func (p *HPCRProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	// TODO: Initialize any shared client or configuration data here
-	// For now, we'll pass nil as the provider doesn't have configuration
-	// The datasource package context will be initialized per resource/datasource
-	resp.DataSourceData = nil
-	resp.ResourceData = nil
+	idMode := common.IDModeRandom
+	if !config.IDMode.IsNull() && !config.IDMode.IsUnknown() {
+		idMode = config.IDMode.ValueString()
+	}
+	if idMode != common.IDModeRandom && idMode != common.IDModeStable {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id_mode"),
+			"Invalid ID mode",
+			fmt.Sprintf("Unsupported id_mode '%s', expected '%s' or '%s'", idMode, common.IDModeRandom, common.IDModeStable),
+		)
+		return
+	}
+
+	// Share the provider configuration with all resources and data sources
+	providerData := &common.ProviderData{
+		IDMode: idMode,
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *HPCRProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -79,15 +105,26 @@ func (p *HPCRProvider) Resources(ctx context.Context) []func() resource.Resource
 		resources.NewTextEncryptedResource,
 		resources.NewJSONResource,
 		resources.NewJSONEncryptedResource,
+		resources.NewYAMLResource,
+		resources.NewYAMLEncryptedResource,
 		resources.NewContractEncryptedResource,
 		resources.NewContractEncryptedContractExpiryResource,
+		resources.NewAttestationKeypairResource,
+		resources.NewCloudInitISOResource,
+		resources.NewPeerPodInitDataResource,
+		resources.NewContractSetResource,
 	}
 }
 
 func (p *HPCRProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		datasources.NewImageDataSource,
+		datasources.NewImagesDataSource,
+		datasources.NewImageBundleDataSource,
+		datasources.NewImageFileDataSource,
+		datasources.NewPlatformsDataSource,
 		datasources.NewAttestationDataSource,
+		datasources.NewAttestationDiffDataSource,
 		datasources.NewEncryptionCertsDataSource,
 		datasources.NewEncryptionCertDataSource,
 	}
@@ -54,6 +54,14 @@ func TestHPCRProvider_Schema(t *testing.T) {
 	if resp.Schema.MarkdownDescription == "" {
 		t.Error("Expected schema to have a markdown description")
 	}
+
+	idMode, ok := resp.Schema.Attributes["id_mode"]
+	if !ok {
+		t.Fatal("Expected schema to have attribute 'id_mode'")
+	}
+	if !idMode.IsOptional() {
+		t.Error("Expected 'id_mode' attribute to be optional")
+	}
 }
 
 func TestHPCRProvider_Configure(t *testing.T) {
@@ -75,7 +83,7 @@ func TestHPCRProvider_Resources(t *testing.T) {
 
 	resources := p.Resources(context.TODO())
 
-	expectedCount := 8
+	expectedCount := 14
 	if len(resources) != expectedCount {
 		t.Errorf("Expected %d resources, got %d", expectedCount, len(resources))
 	}
@@ -94,7 +102,7 @@ func TestHPCRProvider_DataSources(t *testing.T) {
 
 	dataSources := p.DataSources(context.TODO())
 
-	expectedCount := 4
+	expectedCount := 9
 	if len(dataSources) != expectedCount {
 		t.Errorf("Expected %d data sources, got %d", expectedCount, len(dataSources))
 	}
@@ -166,7 +174,7 @@ func TestHPCRProvider_ResourcesList(t *testing.T) {
 	resources := p.Resources(context.TODO())
 
 	// Verify we have the expected resource types
-	expectedResourceCount := 8
+	expectedResourceCount := 14
 
 	if len(resources) != expectedResourceCount {
 		t.Errorf("Expected %d resources, got %d", expectedResourceCount, len(resources))
@@ -186,7 +194,7 @@ func TestHPCRProvider_DataSourcesList(t *testing.T) {
 	dataSources := p.DataSources(context.TODO())
 
 	// Verify we have the expected data source types
-	expectedDataSources := 4 // image, attestation, encryption_certs, encryption_cert
+	expectedDataSources := 9 // image, images, image_bundle, image_file, platforms, attestation, attestation_diff, encryption_certs, encryption_cert
 
 	if len(dataSources) != expectedDataSources {
 		t.Errorf("Expected %d data sources, got %d", expectedDataSources, len(dataSources))
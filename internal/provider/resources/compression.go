@@ -0,0 +1,46 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+// validateCompression checks at plan time that the target runtime is able to
+// decompress the configured compression.
+func validateCompression(compression, platform, version types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Unknown values are validated again once they are known
+	if compression.IsUnknown() || platform.IsUnknown() || version.IsUnknown() {
+		return diags
+	}
+
+	if err := common.ValidateCompressionSupport(compression.ValueString(), platform.ValueString(), version.ValueString()); err != nil {
+		diags.AddAttributeError(
+			path.Root("compression"),
+			"Unsupported compression",
+			fmt.Sprintf("Error validating compression: %s", err.Error()),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+func TestValidateCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression types.String
+		platform    types.String
+		version     types.String
+		expectError bool
+	}{
+		{"not set", types.StringNull(), types.StringNull(), types.StringNull(), false},
+		{"none", types.StringValue(common.CompressionNone), types.StringValue("hpvs"), types.StringValue("1.0.23"), false},
+		{"gzip", types.StringValue(common.CompressionGzip), types.StringNull(), types.StringNull(), true},
+		{"unknown platform", types.StringValue(common.CompressionGzip), types.StringUnknown(), types.StringNull(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateCompression(tt.compression, tt.platform, tt.version)
+			if diags.HasError() != tt.expectError {
+				t.Errorf("Expected error: %v, got %v", tt.expectError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+// validateJSONInput ensures that exactly one of the json and value attributes is configured.
+func validateJSONInput(jsonInput types.String, value types.Dynamic) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Unknown values are validated again once they are known
+	if jsonInput.IsUnknown() || value.IsUnknown() {
+		return diags
+	}
+
+	if jsonInput.IsNull() == value.IsNull() {
+		diags.AddAttributeError(
+			path.Root("value"),
+			"Invalid JSON input",
+			"Exactly one of 'json' or 'value' must be provided.",
+		)
+	}
+
+	return diags
+}
+
+// canonicalJSON returns the canonical JSON serialization of either the json
+// string or the dynamic value, with object keys sorted.
+func canonicalJSON(jsonInput types.String, value types.Dynamic) (string, error) {
+	if !value.IsNull() {
+		data, err := dynamicToInterface(value)
+		if err != nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal value: %v", err)
+		}
+		return string(jsonBytes), nil
+	}
+
+	return common.CanonicalJSON(jsonInput.ValueString())
+}
+
+// dynamicToInterface converts a Terraform value into the generic Go
+// representation used by encoding/json. Numbers are normalized like the
+// numbers of the json attribute, see common.CanonicalNumber.
+func dynamicToInterface(value attr.Value) (interface{}, error) {
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return dynamicToInterface(v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Number:
+		return common.CanonicalNumber(v.ValueBigFloat().Text('f', -1))
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.List:
+		return elementsToInterface(v.Elements())
+	case types.Set:
+		return elementsToInterface(v.Elements())
+	case types.Tuple:
+		return elementsToInterface(v.Elements())
+	case types.Map:
+		return attributesToInterface(v.Elements())
+	case types.Object:
+		return attributesToInterface(v.Attributes())
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+func elementsToInterface(elements []attr.Value) (interface{}, error) {
+	result := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		converted, err := dynamicToInterface(element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+func attributesToInterface(attributes map[string]attr.Value) (interface{}, error) {
+	result := make(map[string]interface{}, len(attributes))
+	for key, element := range attributes {
+		converted, err := dynamicToInterface(element)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = converted
+	}
+	return result, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCanonicalJSON_Value(t *testing.T) {
+	object := types.ObjectValueMust(
+		map[string]attr.Type{
+			"name":  types.StringType,
+			"count": types.NumberType,
+			"tags":  types.ListType{ElemType: types.StringType},
+		},
+		map[string]attr.Value{
+			"name":  types.StringValue("app"),
+			"count": types.NumberValue(big.NewFloat(3)),
+			"tags": types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("a"),
+				types.StringValue("b"),
+			}),
+		},
+	)
+
+	output, err := canonicalJSON(types.StringNull(), types.DynamicValue(object))
+	if err != nil {
+		t.Fatalf("canonicalJSON() failed: %v", err)
+	}
+
+	expected := `{"count":3,"name":"app","tags":["a","b"]}`
+	if output != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, output)
+	}
+}
+
+func TestCanonicalJSON_TopLevelList(t *testing.T) {
+	tuple := types.TupleValueMust(
+		[]attr.Type{types.StringType, types.BoolType, types.NumberType},
+		[]attr.Value{types.StringValue("x"), types.BoolValue(true), types.NumberValue(big.NewFloat(1.5))},
+	)
+
+	output, err := canonicalJSON(types.StringNull(), types.DynamicValue(tuple))
+	if err != nil {
+		t.Fatalf("canonicalJSON() failed: %v", err)
+	}
+
+	expected := `["x",true,1.5]`
+	if output != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, output)
+	}
+}
+
+func TestCanonicalJSON_String(t *testing.T) {
+	output, err := canonicalJSON(types.StringValue(`[{"b": 2, "a": 1}]`), types.DynamicNull())
+	if err != nil {
+		t.Fatalf("canonicalJSON() failed: %v", err)
+	}
+
+	expected := `[{"a":1,"b":2}]`
+	if output != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, output)
+	}
+}
+
+func TestCanonicalJSON_NumbersMatch(t *testing.T) {
+	for _, number := range []string{"1", "1.0", "1e3", "0.1", "-12.50", "2.5e-7", "1e21", "12345678901234567890"} {
+		t.Run(number, func(t *testing.T) {
+			// Terraform parses number literals with 512 bits of precision
+			parsed, _, err := big.ParseFloat(number, 10, 512, big.ToNearestEven)
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", number, err)
+			}
+			object := types.ObjectValueMust(
+				map[string]attr.Type{"a": types.NumberType},
+				map[string]attr.Value{"a": types.NumberValue(parsed)},
+			)
+
+			fromValue, err := canonicalJSON(types.StringNull(), types.DynamicValue(object))
+			if err != nil {
+				t.Fatalf("canonicalJSON() of value failed: %v", err)
+			}
+			fromJSON, err := canonicalJSON(types.StringValue(`{"a": `+number+`}`), types.DynamicNull())
+			if err != nil {
+				t.Fatalf("canonicalJSON() of json failed: %v", err)
+			}
+			if fromValue != fromJSON {
+				t.Errorf("Expected json and value to render the same, got '%s' and '%s'", fromJSON, fromValue)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSON_UnknownValue(t *testing.T) {
+	if _, err := canonicalJSON(types.StringNull(), types.DynamicValue(types.StringUnknown())); err == nil {
+		t.Error("canonicalJSON() should fail for unknown values")
+	}
+}
+
+func TestValidateJSONInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		jsonInput types.String
+		value     types.Dynamic
+		wantErr   bool
+	}{
+		{"json only", types.StringValue("{}"), types.DynamicNull(), false},
+		{"value only", types.StringNull(), types.DynamicValue(types.StringValue("x")), false},
+		{"both", types.StringValue("{}"), types.DynamicValue(types.StringValue("x")), true},
+		{"neither", types.StringNull(), types.DynamicNull(), true},
+		{"unknown", types.StringUnknown(), types.DynamicNull(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateJSONInput(tt.jsonInput, tt.value)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validateJSONInput() errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
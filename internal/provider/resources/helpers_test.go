@@ -0,0 +1,72 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// testPlan returns a plan of the resource schema that holds the given model,
+// and an empty state to receive the result of Create or Update.
+func testPlan(t *testing.T, r resource.Resource, model any) (tfsdk.Plan, tfsdk.State) {
+	t.Helper()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.TODO(), resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(context.TODO(), model); diags.HasError() {
+		t.Fatalf("Failed to build plan: %v", diags)
+	}
+
+	return tfsdk.Plan{Schema: schemaResp.Schema, Raw: state.Raw}, tfsdk.State{Schema: schemaResp.Schema}
+}
+
+// testCreate runs Create of the resource for the given model and reads the
+// resulting state back into result.
+func testCreate(t *testing.T, r resource.Resource, model any, result any) {
+	t.Helper()
+
+	plan, state := testPlan(t, r, model)
+	resp := &resource.CreateResponse{State: state}
+	r.Create(context.TODO(), resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create failed: %v", resp.Diagnostics)
+	}
+	if diags := resp.State.Get(context.TODO(), result); diags.HasError() {
+		t.Fatalf("Failed to read state: %v", diags)
+	}
+}
+
+// testUpdate runs Update of the resource from the prior state to the given
+// model and reads the resulting state back into result.
+func testUpdate(t *testing.T, r resource.Resource, prior any, model any, result any) {
+	t.Helper()
+
+	priorState, _ := testPlan(t, r, prior)
+	plan, state := testPlan(t, r, model)
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(context.TODO(), resource.UpdateRequest{Plan: plan, State: tfsdk.State(priorState)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update failed: %v", resp.Diagnostics)
+	}
+	if diags := resp.State.Get(context.TODO(), result); diags.HasError() {
+		t.Fatalf("Failed to read state: %v", diags)
+	}
+}
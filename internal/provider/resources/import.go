@@ -0,0 +1,160 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+// importedPrivateKey is the private state key that marks a resource whose
+// outputs were imported rather than generated by the provider. The mark is
+// removed by the first update after the import.
+const importedPrivateKey = "imported"
+
+// privateState is the private state of a resource as passed to the CRUD and
+// plan modifier functions.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// splitImportID splits an import ID of the form <resource>:<value>[:<value>...]
+// into at most n values, the last one holding the remainder of the ID. The
+// resource name may be given with or without the hpcr_ prefix. It reports
+// false if the ID names a different resource or the first value is empty.
+func splitImportID(id, resourceName string, n int) ([]string, bool) {
+	parts := strings.SplitN(id, ":", n+1)
+	if len(parts) < 2 || strings.TrimPrefix(parts[0], "hpcr_") != resourceName || parts[1] == "" {
+		return nil, false
+	}
+	return parts[1:], true
+}
+
+// isSha256 reports whether value is a hex encoded SHA256 checksum.
+func isSha256(value string) bool {
+	decoded, err := hex.DecodeString(value)
+	return err == nil && len(decoded) == 32
+}
+
+// isImported reports whether the resource carries the import mark.
+func isImported(ctx context.Context, private privateState) (bool, diag.Diagnostics) {
+	if private == nil {
+		return false, nil
+	}
+	value, diags := private.GetKey(ctx, importedPrivateKey)
+	return len(value) > 0, diags
+}
+
+// requiresReplaceUnlessImported replaces the resource when the attribute
+// changes, except for the first update after an import, which adopts the
+// configured value.
+func requiresReplaceUnlessImported() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			imported, diags := isImported(ctx, req.Private)
+			resp.Diagnostics.Append(diags...)
+			resp.RequiresReplace = !imported
+		},
+		"Changing the value replaces the resource, unless the resource was just imported.",
+		"Changing the value replaces the resource, unless the resource was just imported.",
+	)
+}
+
+// mapRequiresReplaceUnlessImported is the map variant of requiresReplaceUnlessImported.
+func mapRequiresReplaceUnlessImported() planmodifier.Map {
+	return mapplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+			imported, diags := isImported(ctx, req.Private)
+			resp.Diagnostics.Append(diags...)
+			resp.RequiresReplace = !imported
+		},
+		"Changing the value replaces the resource, unless the resource was just imported.",
+		"Changing the value replaces the resource, unless the resource was just imported.",
+	)
+}
+
+// importRendered imports a resource with a rendered output from an ID of the
+// form <resource>:<sha256_in>[:<rendered>]. The rendered output is optional
+// and without it the next apply generates the output again.
+func importRendered(ctx context.Context, resourceName, idMode string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, ok := splitImportID(req.ID, resourceName, 2)
+	if !ok || !isSha256(parts[0]) {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form %s:<sha256_in>[:<rendered>], got '%s'", resourceName, req.ID),
+		)
+		return
+	}
+	sha256In := parts[0]
+
+	id, err := common.ResourceID(idMode, sha256In)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sha256_in"), sha256In)...)
+	if len(parts) == 2 && parts[1] != "" {
+		rendered := parts[1]
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rendered"), rendered)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("sha256_out"), common.GenerateSha256(rendered))...)
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, []byte("true"))...)
+}
+
+// importedRendered returns the rendered output of an imported resource if
+// the sha256_in given on import matches inputHash, the hash of the configured
+// input. If the input does not match, a warning is added and the caller has
+// to generate the output again.
+func importedRendered(ctx context.Context, private privateState, state tfsdk.State, inputHash string) (string, bool, diag.Diagnostics) {
+	imported, diags := isImported(ctx, private)
+	if diags.HasError() || !imported {
+		return "", false, diags
+	}
+
+	var sha256In, rendered types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("sha256_in"), &sha256In)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("rendered"), &rendered)...)
+	if diags.HasError() || rendered.IsNull() {
+		return "", false, diags
+	}
+
+	if sha256In.ValueString() != inputHash {
+		diags.AddWarning(
+			"Imported output replaced",
+			fmt.Sprintf("The input has SHA256 %s but the resource was imported with sha256_in %s, the rendered output is generated again", inputHash, sha256In.ValueString()),
+		)
+		return "", false, diags
+	}
+
+	return rendered.ValueString(), true, diags
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+const testSha256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestSplitImportID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		resource string
+		n        int
+		want     []string
+		wantOk   bool
+	}{
+		{"hash only", "text_encrypted:" + testSha256, "text_encrypted", 2, []string{testSha256}, true},
+		{"hash and rendered", "text_encrypted:" + testSha256 + ":hyper-protect-basic.a.b", "text_encrypted", 2, []string{testSha256, "hyper-protect-basic.a.b"}, true},
+		{"provider prefix", "hpcr_text_encrypted:" + testSha256, "text_encrypted", 2, []string{testSha256}, true},
+		{"remainder with colons", "cloudinit_iso:C:\\images\\cidata.iso", "cloudinit_iso", 1, []string{"C:\\images\\cidata.iso"}, true},
+		{"other resource", "text:" + testSha256, "text_encrypted", 2, nil, false},
+		{"missing value", "text_encrypted", "text_encrypted", 2, nil, false},
+		{"empty value", "text_encrypted:", "text_encrypted", 2, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := splitImportID(tt.id, tt.resource, tt.n)
+
+			if ok != tt.wantOk {
+				t.Fatalf("splitImportID() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitImportID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSha256(t *testing.T) {
+	if !isSha256(testSha256) {
+		t.Error("Expected a SHA256 checksum to be accepted")
+	}
+	if isSha256(testSha256[:62]) {
+		t.Error("Expected a short checksum to be rejected")
+	}
+	if isSha256(strings.Repeat("z", 64)) {
+		t.Error("Expected a non hex checksum to be rejected")
+	}
+}
+
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func TestIsImported(t *testing.T) {
+	tests := []struct {
+		name    string
+		private privateState
+		want    bool
+	}{
+		{"no private state", nil, false},
+		{"not imported", testPrivateState{}, false},
+		{"imported", testPrivateState{importedPrivateKey: []byte("true")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := isImported(context.TODO(), tt.private)
+			if diags.HasError() {
+				t.Fatalf("isImported() failed: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("isImported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportState_InvalidID(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource resource.ResourceWithImportState
+	}{
+		{"text", &TextResource{}},
+		{"text_encrypted", &TextEncryptedResource{}},
+		{"json", &JSONResource{}},
+		{"json_encrypted", &JSONEncryptedResource{}},
+		{"yaml", &YAMLResource{}},
+		{"yaml_encrypted", &YAMLEncryptedResource{}},
+		{"tgz", &TgzResource{}},
+		{"tgz_encrypted", &TgzEncryptedResource{}},
+		{"contract_encrypted", &ContractEncryptedResource{}},
+		{"contract_encrypted_contract_expiry", &ContractEncryptedContractExpiryResource{}},
+		{"attestation_keypair", &AttestationKeypairResource{}},
+		{"cloudinit_iso", &CloudInitISOResource{}},
+		{"peerpod_initdata", &PeerPodInitDataResource{}},
+		{"contract_set", &ContractSetResource{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, id := range []string{"", tt.name, "other:" + testSha256} {
+				resp := &resource.ImportStateResponse{}
+				tt.resource.ImportState(context.TODO(), resource.ImportStateRequest{ID: id}, resp)
+				if !resp.Diagnostics.HasError() {
+					t.Errorf("Expected ImportState to reject the ID '%s'", id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+// providerIDMode returns the ID mode configured on the provider, or
+// common.IDModeRandom while the provider is not configured yet.
+func providerIDMode(req resource.ConfigureRequest, resp *resource.ConfigureResponse) string {
+	if req.ProviderData == nil {
+		return common.IDModeRandom
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T", req.ProviderData),
+		)
+		return common.IDModeRandom
+	}
+
+	return providerData.IDMode
+}
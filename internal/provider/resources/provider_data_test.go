@@ -0,0 +1,51 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+func TestProviderIDMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerData any
+		want         string
+		wantErr      bool
+	}{
+		{"not configured", nil, common.IDModeRandom, false},
+		{"random", &common.ProviderData{IDMode: common.IDModeRandom}, common.IDModeRandom, false},
+		{"stable", &common.ProviderData{IDMode: common.IDModeStable}, common.IDModeStable, false},
+		{"unexpected type", "stable", common.IDModeRandom, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ConfigureResponse{}
+			got := providerIDMode(resource.ConfigureRequest{ProviderData: tt.providerData}, resp)
+
+			if got != tt.want {
+				t.Errorf("providerIDMode() = %q, want %q", got, tt.want)
+			}
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("providerIDMode() error = %v, wantErr %v", resp.Diagnostics, tt.wantErr)
+			}
+		})
+	}
+}
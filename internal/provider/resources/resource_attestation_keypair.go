@@ -0,0 +1,273 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ resource.Resource = &AttestationKeypairResource{}
+var _ resource.ResourceWithConfigure = &AttestationKeypairResource{}
+var _ resource.ResourceWithImportState = &AttestationKeypairResource{}
+
+func NewAttestationKeypairResource() resource.Resource {
+	return &AttestationKeypairResource{}
+}
+
+type AttestationKeypairResource struct {
+	idMode string
+}
+
+type AttestationKeypairResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Keepers         types.Map    `tfsdk:"keepers"`
+	PrivateKey      types.String `tfsdk:"private_key"`
+	PublicKey       types.String `tfsdk:"public_key"`
+	PublicKeyBase64 types.String `tfsdk:"public_key_base64"`
+	Sha256Out       types.String `tfsdk:"sha256_out"`
+}
+
+func (r *AttestationKeypairResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attestation_keypair"
+}
+
+func (r *AttestationKeypairResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates an RSA key pair for encrypted attestation records. The public key is set as `attestationPublicKey` in the contract and the private key decrypts the records with `hpcr_attestation`.",
+		Description:         "Generates an RSA key pair for encrypted attestation records.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, forces a new key pair to be generated",
+				Description:         "Arbitrary map of values that, when changed, forces a new key pair to be generated",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapRequiresReplaceUnlessImported(),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "RSA private key in PEM format, used as `privkey` of `hpcr_attestation` to decrypt the attestation records",
+				Description:         "RSA private key in PEM format",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "RSA public key in PEM format",
+				Description:         "RSA public key in PEM format",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded public key, ready to be used as `attestationPublicKey` in the contract",
+				Description:         "Base64 encoded public key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sha256_out": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the public key",
+				Description:         "SHA256 of the public key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AttestationKeypairResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
+func (r *AttestationKeypairResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AttestationKeypairResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate the attestation key pair
+	privateKey, err := common.GeneratePrivateKey()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate private key",
+			fmt.Sprintf("Error generating private key: %s", err.Error()),
+		)
+		return
+	}
+
+	publicKey, err := common.PublicKeyFromPrivateKey(privateKey, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate public key",
+			fmt.Sprintf("Error generating public key: %s", err.Error()),
+		)
+		return
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, common.GenerateSha256(publicKey))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields
+	data.ID = types.StringValue(id)
+	data.PrivateKey = types.StringValue(privateKey)
+	data.PublicKey = types.StringValue(publicKey)
+	data.PublicKeyBase64 = types.StringValue(base64.StdEncoding.EncodeToString([]byte(publicKey)))
+	data.Sha256Out = types.StringValue(common.GenerateSha256(publicKey))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AttestationKeypairResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AttestationKeypairResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AttestationKeypairResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AttestationKeypairResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Any change of keepers replaces the resource, except right after an import
+	// where the configured keepers are adopted, so the key pair is kept as it is
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AttestationKeypairResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No-op
+}
+
+func (r *AttestationKeypairResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	privateKey, diags := importedPrivateKeyFrom(req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	publicKey, err := common.PublicKeyFromPrivateKey(privateKey, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate public key",
+			fmt.Sprintf("Error generating public key: %s", err.Error()),
+		)
+		return
+	}
+
+	id, err := common.ResourceID(r.idMode, common.GenerateSha256(publicKey))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	data := AttestationKeypairResourceModel{
+		ID:              types.StringValue(id),
+		Keepers:         types.MapNull(types.StringType),
+		PrivateKey:      types.StringValue(privateKey),
+		PublicKey:       types.StringValue(publicKey),
+		PublicKeyBase64: types.StringValue(base64.StdEncoding.EncodeToString([]byte(publicKey))),
+		Sha256Out:       types.StringValue(common.GenerateSha256(publicKey)),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, []byte("true"))...)
+}
+
+// importedPrivateKeyFrom reads the private key named by an import ID of the
+// form attestation_keypair:file:<path> or attestation_keypair:env:<variable>.
+// The ID names the source of the private key, never the key itself, so that
+// the key does not end up in the shell history or in logs. The ID is not
+// repeated in diagnostics for the same reason.
+func importedPrivateKeyFrom(id string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	parts, ok := splitImportID(id, "attestation_keypair", 2)
+	if !ok || len(parts) != 2 || parts[1] == "" {
+		diags.AddError(
+			"Invalid import ID",
+			"Expected an import ID of the form attestation_keypair:file:<path> or attestation_keypair:env:<variable>",
+		)
+		return "", diags
+	}
+
+	switch source, name := parts[0], parts[1]; source {
+	case "file":
+		privateKey, err := common.ReadFileData(name)
+		if err != nil {
+			diags.AddError(
+				"Failed to read private key",
+				fmt.Sprintf("Error reading the private key file: %s", err.Error()),
+			)
+		}
+		return privateKey, diags
+	case "env":
+		privateKey := os.Getenv(name)
+		if privateKey == "" {
+			diags.AddError(
+				"Failed to read private key",
+				fmt.Sprintf("The environment variable %s is not set or empty", name),
+			)
+		}
+		return privateKey, diags
+	default:
+		diags.AddError(
+			"Invalid import ID",
+			"Expected an import ID of the form attestation_keypair:file:<path> or attestation_keypair:env:<variable>",
+		)
+		return "", diags
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+func TestAttestationKeypairResource_Metadata(t *testing.T) {
+	r := NewAttestationKeypairResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_attestation_keypair" {
+		t.Errorf("Expected TypeName to be 'hpcr_attestation_keypair', got '%s'", resp.TypeName)
+	}
+}
+
+func TestAttestationKeypairResource_Schema(t *testing.T) {
+	r := NewAttestationKeypairResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify schema has required attributes
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	requiredAttrs := []string{"id", "keepers", "private_key", "public_key", "public_key_base64", "sha256_out"}
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected schema to have attribute '%s'", attr)
+		}
+	}
+
+	// Verify keepers is optional
+	keepersAttr := resp.Schema.Attributes["keepers"]
+	if keepersAttr.IsOptional() == false {
+		t.Error("Expected 'keepers' attribute to be optional")
+	}
+
+	// Verify private_key is sensitive
+	privateKeyAttr := resp.Schema.Attributes["private_key"]
+	if privateKeyAttr.IsSensitive() == false {
+		t.Error("Expected 'private_key' attribute to be marked as sensitive")
+	}
+
+	// Verify computed attributes
+	computedAttrs := []string{"id", "private_key", "public_key", "public_key_base64", "sha256_out"}
+	for _, attr := range computedAttrs {
+		if resp.Schema.Attributes[attr].IsComputed() == false {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+}
+
+func TestNewAttestationKeypairResource(t *testing.T) {
+	r := NewAttestationKeypairResource()
+	if r == nil {
+		t.Fatal("NewAttestationKeypairResource should not return nil")
+	}
+
+	// Verify it implements the Resource interface
+	var _ resource.Resource = &AttestationKeypairResource{}
+}
+
+func TestAttestationKeypairResource_SchemaDescriptions(t *testing.T) {
+	r := NewAttestationKeypairResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify schema has descriptions
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	// Verify attributes have descriptions (either Description or MarkdownDescription)
+	for name, attr := range resp.Schema.Attributes {
+		desc := attr.GetDescription()
+		mdDesc := attr.GetMarkdownDescription()
+		if desc == "" && mdDesc == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
+
+func TestAttestationKeypairResource_Delete(t *testing.T) {
+	r := &AttestationKeypairResource{}
+
+	req := resource.DeleteRequest{}
+	resp := &resource.DeleteResponse{}
+
+	// Delete should be a no-op and not produce any errors
+	r.Delete(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Delete should not produce errors")
+	}
+}
+
+func TestImportedPrivateKeyFrom(t *testing.T) {
+	privateKey, err := common.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey() failed: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "private.pem")
+	if err := os.WriteFile(keyFile, []byte(privateKey), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HPCR_TEST_ATTESTATION_KEY", privateKey)
+
+	for _, id := range []string{"attestation_keypair:file:" + keyFile, "hpcr_attestation_keypair:env:HPCR_TEST_ATTESTATION_KEY"} {
+		key, diags := importedPrivateKeyFrom(id)
+		if diags.HasError() {
+			t.Fatalf("importedPrivateKeyFrom(%s) failed: %v", id, diags)
+		}
+		if key != privateKey {
+			t.Errorf("Expected the private key from %s", id)
+		}
+	}
+
+	// The key itself is not accepted as ID and the ID is never echoed in diagnostics
+	inline := base64.StdEncoding.EncodeToString([]byte(privateKey))
+	for _, id := range []string{"attestation_keypair:" + inline, "attestation_keypair:pem:" + inline, "attestation_keypair:env:HPCR_TEST_UNSET_KEY"} {
+		_, diags := importedPrivateKeyFrom(id)
+		if !diags.HasError() {
+			t.Fatalf("Expected an error for the import ID %.40s...", id)
+		}
+		for _, d := range diags {
+			if strings.Contains(d.Detail(), inline[:32]) {
+				t.Errorf("Expected the import ID not to be echoed, got %q", d.Detail())
+			}
+		}
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+// defaultVendorData is the vendor-data used by HPCR on KVM when none is configured.
+const defaultVendorData = "#cloud-config\nusers:\n- default\n"
+
+var _ resource.Resource = &CloudInitISOResource{}
+var _ resource.ResourceWithConfigure = &CloudInitISOResource{}
+var _ resource.ResourceWithImportState = &CloudInitISOResource{}
+
+func NewCloudInitISOResource() resource.Resource {
+	return &CloudInitISOResource{}
+}
+
+type CloudInitISOResource struct {
+	idMode string
+}
+
+type CloudInitISOResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	UserData   types.String `tfsdk:"user_data"`
+	MetaData   types.String `tfsdk:"meta_data"`
+	VendorData types.String `tfsdk:"vendor_data"`
+	Path       types.String `tfsdk:"path"`
+	Size       types.Int64  `tfsdk:"size"`
+	Sha256Out  types.String `tfsdk:"sha256_out"`
+}
+
+func (r *CloudInitISOResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloudinit_iso"
+}
+
+func (r *CloudInitISOResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Writes a cloud-init NoCloud ISO image with the volume label `cidata` that passes the contract to HPCR on KVM as `user-data`.",
+		Description:         "Writes a cloud-init NoCloud ISO image for HPCR on KVM.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_data": schema.StringAttribute{
+				MarkdownDescription: "Content of the `user-data` file, typically the `rendered` contract of `hpcr_contract_encrypted`",
+				Description:         "Content of the user-data file",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessImported(),
+				},
+			},
+			"meta_data": schema.StringAttribute{
+				MarkdownDescription: "Content of the `meta-data` file. Defaults to an empty file.",
+				Description:         "Content of the meta-data file",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessImported(),
+				},
+			},
+			"vendor_data": schema.StringAttribute{
+				MarkdownDescription: "Content of the `vendor-data` file. Defaults to a cloud-config that enables the default user.",
+				Description:         "Content of the vendor-data file",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessImported(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the ISO image file to write",
+				Description:         "Path of the ISO image file to write",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the ISO image in bytes",
+				Description:         "Size of the ISO image in bytes",
+				Computed:            true,
+			},
+			"sha256_out": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the ISO image",
+				Description:         "SHA256 of the ISO image",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *CloudInitISOResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
+func (r *CloudInitISOResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CloudInitISOResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vendorData := defaultVendorData
+	if !data.VendorData.IsNull() {
+		vendorData = data.VendorData.ValueString()
+	}
+
+	files := []common.ISOFile{
+		{Name: "user-data", Content: []byte(data.UserData.ValueString())},
+		{Name: "meta-data", Content: []byte(data.MetaData.ValueString())},
+		{Name: "vendor-data", Content: []byte(vendorData)},
+	}
+
+	// Use a fixed timestamp so that the same input yields the same image
+	image, err := common.CreateISO(common.CloudInitVolumeID, files, time.Unix(0, 0))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create ISO image",
+			fmt.Sprintf("Error creating cloud-init ISO image: %s", err.Error()),
+		)
+		return
+	}
+
+	path := data.Path.ValueString()
+	if err := os.WriteFile(path, image, 0644); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to write ISO image",
+			fmt.Sprintf("Error writing cloud-init ISO image to '%s': %s", path, err.Error()),
+		)
+		return
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, path, common.GenerateSha256(string(image)))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields
+	data.ID = types.StringValue(id)
+	data.Size = types.Int64Value(int64(len(image)))
+	data.Sha256Out = types.StringValue(common.GenerateSha256(string(image)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CloudInitISOResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CloudInitISOResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Recreate the ISO image if it was deleted or modified outside of Terraform
+	path := data.Path.ValueString()
+	image, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		tflog.Info(ctx, fmt.Sprintf("ISO image '%s' no longer exists, removing it from state", path))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read ISO image",
+			fmt.Sprintf("Error reading cloud-init ISO image '%s': %s", path, err.Error()),
+		)
+		return
+	}
+	if common.GenerateSha256(string(image)) != data.Sha256Out.ValueString() {
+		tflog.Info(ctx, fmt.Sprintf("ISO image '%s' was modified, removing it from state", path))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CloudInitISOResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CloudInitISOResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Any change of the input replaces the resource, except right after an import
+	// where the configured files are adopted and the image is written again if it differs
+	vendorData := defaultVendorData
+	if !data.VendorData.IsNull() {
+		vendorData = data.VendorData.ValueString()
+	}
+
+	files := []common.ISOFile{
+		{Name: "user-data", Content: []byte(data.UserData.ValueString())},
+		{Name: "meta-data", Content: []byte(data.MetaData.ValueString())},
+		{Name: "vendor-data", Content: []byte(vendorData)},
+	}
+
+	// Use a fixed timestamp so that the same input yields the same image
+	image, err := common.CreateISO(common.CloudInitVolumeID, files, time.Unix(0, 0))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create ISO image",
+			fmt.Sprintf("Error creating cloud-init ISO image: %s", err.Error()),
+		)
+		return
+	}
+
+	var state CloudInitISOResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	path := data.Path.ValueString()
+	sha256Out := common.GenerateSha256(string(image))
+	if sha256Out != state.Sha256Out.ValueString() {
+		tflog.Info(ctx, fmt.Sprintf("ISO image '%s' differs from the configured files, writing it again", path))
+		if err := os.WriteFile(path, image, 0644); err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to write ISO image",
+				fmt.Sprintf("Error writing cloud-init ISO image to '%s': %s", path, err.Error()),
+			)
+			return
+		}
+	}
+
+	// Set the computed fields (keep the existing ID)
+	data.Size = types.Int64Value(int64(len(image)))
+	data.Sha256Out = types.StringValue(sha256Out)
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CloudInitISOResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CloudInitISOResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	path := data.Path.ValueString()
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		resp.Diagnostics.AddError(
+			"Failed to delete ISO image",
+			fmt.Sprintf("Error deleting cloud-init ISO image '%s': %s", path, err.Error()),
+		)
+	}
+}
+
+func (r *CloudInitISOResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, ok := splitImportID(req.ID, "cloudinit_iso", 1)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form cloudinit_iso:<path>, got '%s'", req.ID),
+		)
+		return
+	}
+
+	path := parts[0]
+	image, err := os.ReadFile(path)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read ISO image",
+			fmt.Sprintf("Error reading cloud-init ISO image '%s': %s", path, err.Error()),
+		)
+		return
+	}
+
+	sha256Out := common.GenerateSha256(string(image))
+	id, err := common.ResourceID(r.idMode, path, sha256Out)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	data := CloudInitISOResourceModel{
+		ID:         types.StringValue(id),
+		UserData:   types.StringNull(),
+		MetaData:   types.StringNull(),
+		VendorData: types.StringNull(),
+		Path:       types.StringValue(path),
+		Size:       types.Int64Value(int64(len(image))),
+		Sha256Out:  types.StringValue(sha256Out),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, []byte("true"))...)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func TestCloudInitISOResource_Metadata(t *testing.T) {
+	r := NewCloudInitISOResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_cloudinit_iso" {
+		t.Errorf("Expected TypeName to be 'hpcr_cloudinit_iso', got '%s'", resp.TypeName)
+	}
+}
+
+func TestCloudInitISOResource_Schema(t *testing.T) {
+	r := NewCloudInitISOResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify schema has required attributes
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	requiredAttrs := []string{"id", "user_data", "meta_data", "vendor_data", "path", "size", "sha256_out"}
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected schema to have attribute '%s'", attr)
+		}
+	}
+
+	// Verify required attributes
+	for _, attr := range []string{"user_data", "path"} {
+		if resp.Schema.Attributes[attr].IsRequired() == false {
+			t.Errorf("Expected '%s' attribute to be required", attr)
+		}
+	}
+
+	// Verify optional attributes
+	for _, attr := range []string{"meta_data", "vendor_data"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
+	// Verify computed attributes
+	for _, attr := range []string{"id", "size", "sha256_out"} {
+		if resp.Schema.Attributes[attr].IsComputed() == false {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+}
+
+func TestNewCloudInitISOResource(t *testing.T) {
+	r := NewCloudInitISOResource()
+	if r == nil {
+		t.Fatal("NewCloudInitISOResource should not return nil")
+	}
+
+	// Verify it implements the Resource interface
+	var _ resource.Resource = &CloudInitISOResource{}
+}
+
+func TestCloudInitISOResource_SchemaDescriptions(t *testing.T) {
+	r := NewCloudInitISOResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify schema has descriptions
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	// Verify attributes have descriptions (either Description or MarkdownDescription)
+	for name, attr := range resp.Schema.Attributes {
+		desc := attr.GetDescription()
+		mdDesc := attr.GetMarkdownDescription()
+		if desc == "" && mdDesc == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
+
+func TestCloudInitISOResource_OutputsNotKeptFromState(t *testing.T) {
+	r := NewCloudInitISOResource()
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.TODO(), resource.SchemaRequest{}, resp)
+
+	// Update writes a new image, so the outputs must not be planned from the state
+	if modifiers := resp.Schema.Attributes["size"].(schema.Int64Attribute).PlanModifiers; len(modifiers) != 0 {
+		t.Errorf("Expected no plan modifiers on 'size', got %d", len(modifiers))
+	}
+	if modifiers := resp.Schema.Attributes["sha256_out"].(schema.StringAttribute).PlanModifiers; len(modifiers) != 0 {
+		t.Errorf("Expected no plan modifiers on 'sha256_out', got %d", len(modifiers))
+	}
+}
@@ -17,25 +17,35 @@ package resources
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
 )
 
 var _ resource.Resource = &ContractEncryptedResource{}
+var _ resource.ResourceWithConfigure = &ContractEncryptedResource{}
+var _ resource.ResourceWithImportState = &ContractEncryptedResource{}
 
 func NewContractEncryptedResource() resource.Resource {
 	return &ContractEncryptedResource{}
 }
 
-type ContractEncryptedResource struct{}
+type ContractEncryptedResource struct {
+	idMode string
+}
 
 type ContractEncryptedResourceModel struct {
 	ID        types.String `tfsdk:"id"`
@@ -77,11 +87,17 @@ func (r *ContractEncryptedResource) Schema(ctx context.Context, req resource.Sch
 				MarkdownDescription: "Hyper Protect platform where this contract will be deployed. Defaults to hpvs",
 				Description:         "Hyper Protect platform where this contract will be deployed",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.Platform(),
+				},
 			},
 			"version": schema.StringAttribute{
 				MarkdownDescription: "Version of the Hyper Protect Platform",
 				Description:         "Version of the Hyper Protect Platform",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.PlatformVersion(path.Root("platform")),
+				},
 			},
 			"cert": schema.StringAttribute{
 				MarkdownDescription: "Certificate used to encrypt the contract, in PEM format. Defaults to the latest HPCR image certificate if not specified.",
@@ -119,6 +135,10 @@ func (r *ContractEncryptedResource) Schema(ctx context.Context, req resource.Sch
 	}
 }
 
+func (r *ContractEncryptedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
 func (r *ContractEncryptedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ContractEncryptedResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -175,8 +195,13 @@ func (r *ContractEncryptedResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	// Generate signed and encrypted contract using the contract-go library
-	signedContract, inputHash, outputHash, err := contract.HpcrContractSignedEncrypted(refinedContract, platform, version, cert, privKey, password)
+	encryptedSections, diags := encryptedContractSections(refinedContract, data.PrivKey.ValueString() != "", true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	signedContract, inputHash, outputHash, err := signContract(refinedContract, encryptedSections, platform, version, cert, privKey, password)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create signed encrypted contract",
@@ -185,8 +210,8 @@ func (r *ContractEncryptedResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash, platform, version, common.GenerateSha256(cert))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -270,10 +295,31 @@ func (r *ContractEncryptedResource) Update(ctx context.Context, req resource.Upd
 			"Failed to refine contract",
 			fmt.Sprintf("Error refining contract: %s", err.Error()),
 		)
+		return
 	}
 
-	// Generate signed and encrypted contract using the contract-go library
-	signedContract, inputHash, outputHash, err := contract.HpcrContractSignedEncrypted(refinedContract, platform, version, cert, privKey, password)
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(refinedContract))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(refinedContract))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	encryptedSections, diags := encryptedContractSections(refinedContract, data.PrivKey.ValueString() != "", true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	signedContract, inputHash, outputHash, err := signContract(refinedContract, encryptedSections, platform, version, cert, privKey, password)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create signed encrypted contract",
@@ -293,3 +339,66 @@ func (r *ContractEncryptedResource) Update(ctx context.Context, req resource.Upd
 func (r *ContractEncryptedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op
 }
+
+// encryptedContractSections returns the sections of a refined contract that are
+// already encrypted. An encrypted env section carries its signing key, so it
+// requires a configured privkey. Resources that cannot reuse encrypted sections
+// pass allowEncrypted false to reject them.
+func encryptedContractSections(refinedContract string, privKeyConfigured, allowEncrypted bool) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	encryptedSections, err := common.EncryptedSections(refinedContract)
+	if err != nil {
+		diags.AddError(
+			"Failed to refine contract",
+			fmt.Sprintf("Error reading contract sections: %s", err.Error()),
+		)
+		return nil, diags
+	}
+	if len(encryptedSections) > 0 && !allowEncrypted {
+		diags.AddError(
+			"Pre-encrypted sections are not supported",
+			fmt.Sprintf("The sections %s are already encrypted. Contracts with an expiry must be built from plaintext sections, use hpcr_contract_encrypted for contracts with pre-encrypted sections.", strings.Join(encryptedSections, ", ")),
+		)
+		return nil, diags
+	}
+	if slices.Contains(encryptedSections, "env") && !privKeyConfigured {
+		diags.AddError(
+			"Missing signing key",
+			"The env section is already encrypted, so 'privkey' must be the key whose public key was embedded as signingKey.",
+		)
+		return nil, diags
+	}
+
+	return encryptedSections, diags
+}
+
+// signContract signs and encrypts a refined contract and returns the rendered
+// contract with the SHA256 of input and output. Contracts with pre-encrypted
+// sections are assembled by the provider, which keeps those sections as they
+// are and computes envWorkloadSignature over the final ciphertexts.
+func signContract(refinedContract string, encryptedSections []string, platform, version, cert, privKey, password string) (string, string, string, error) {
+	if len(encryptedSections) > 0 {
+		signedContract, err := common.AssembleContract(refinedContract, encryptSection(platform, version, cert), privKey, password)
+		if err != nil {
+			return "", "", "", err
+		}
+		return signedContract, common.GenerateSha256(refinedContract), common.GenerateSha256(signedContract), nil
+	}
+
+	// Generate signed and encrypted contract using the contract-go library
+	return contract.HpcrContractSignedEncrypted(refinedContract, platform, version, cert, privKey, password)
+}
+
+// encryptSection returns a function that encrypts a single contract section for
+// the given platform, version and certificate.
+func encryptSection(platform, version, cert string) func(string) (string, error) {
+	return func(text string) (string, error) {
+		encrypted, _, _, err := contract.HpcrTextEncrypted(text, platform, version, cert)
+		return encrypted, err
+	}
+}
+
+func (r *ContractEncryptedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "contract_encrypted", r.idMode, req, resp)
+}
@@ -18,25 +18,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
 )
 
 var _ resource.Resource = &ContractEncryptedContractExpiryResource{}
+var _ resource.ResourceWithConfigure = &ContractEncryptedContractExpiryResource{}
+var _ resource.ResourceWithImportState = &ContractEncryptedContractExpiryResource{}
+var _ resource.ResourceWithMoveState = &ContractEncryptedContractExpiryResource{}
 
 func NewContractEncryptedContractExpiryResource() resource.Resource {
 	return &ContractEncryptedContractExpiryResource{}
 }
 
-type ContractEncryptedContractExpiryResource struct{}
+type ContractEncryptedContractExpiryResource struct {
+	idMode string
+}
 
 type ContractEncryptedContractExpiryResourceModel struct {
 	ID         types.String `tfsdk:"id"`
@@ -88,11 +97,17 @@ func (r *ContractEncryptedContractExpiryResource) Schema(ctx context.Context, re
 				MarkdownDescription: "Hyper Protect platform where this contract will be deployed. Defaults to hpvs",
 				Description:         "Hyper Protect platform where this contract will be deployed",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.Platform(),
+				},
 			},
 			"version": schema.StringAttribute{
 				MarkdownDescription: "Version of the Hyper Protect Platform",
 				Description:         "Version of the Hyper Protect Platform",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.PlatformVersion(path.Root("platform")),
+				},
 			},
 			"privkey": schema.StringAttribute{
 				MarkdownDescription: "Private key used to sign the contract. If omitted, a temporary signing key is created.",
@@ -152,6 +167,10 @@ func (r *ContractEncryptedContractExpiryResource) Schema(ctx context.Context, re
 	}
 }
 
+func (r *ContractEncryptedContractExpiryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
 func (r *ContractEncryptedContractExpiryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ContractEncryptedContractExpiryResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -212,6 +231,13 @@ func (r *ContractEncryptedContractExpiryResource) Create(ctx context.Context, re
 		return
 	}
 
+	// The contract expiry flow signs with a generated certificate and cannot reuse pre-encrypted sections
+	_, diags := encryptedContractSections(refinedContract, data.PrivKey.ValueString() != "", false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert CSR params map to JSON string if provided
 	var csrDataStr string
 	if !data.CsrParams.IsNull() && !data.CsrParams.IsUnknown() {
@@ -252,8 +278,8 @@ func (r *ContractEncryptedContractExpiryResource) Create(ctx context.Context, re
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash, platform, version, common.GenerateSha256(cert))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -344,6 +370,28 @@ func (r *ContractEncryptedContractExpiryResource) Update(ctx context.Context, re
 		return
 	}
 
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(refinedContract))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(refinedContract))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// The contract expiry flow signs with a generated certificate and cannot reuse pre-encrypted sections
+	_, diags = encryptedContractSections(refinedContract, data.PrivKey.ValueString() != "", false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert CSR params map to JSON string if provided
 	var csrDataStr string
 	if !data.CsrParams.IsNull() && !data.CsrParams.IsUnknown() {
@@ -395,3 +443,56 @@ func (r *ContractEncryptedContractExpiryResource) Update(ctx context.Context, re
 func (r *ContractEncryptedContractExpiryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op
 }
+
+func (r *ContractEncryptedContractExpiryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "contract_encrypted_contract_expiry", r.idMode, req, resp)
+}
+
+func (r *ContractEncryptedContractExpiryResource) MoveState(ctx context.Context) []resource.StateMover {
+	sourceSchema := &resource.SchemaResponse{}
+	(&ContractEncryptedResource{}).Schema(ctx, resource.SchemaRequest{}, sourceSchema)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &sourceSchema.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != "hpcr_contract_encrypted" || !strings.HasSuffix(req.SourceProviderAddress, "ibm-hyper-protect/hpcr") {
+					return
+				}
+				if req.SourceSchemaVersion != sourceSchema.Schema.Version || req.SourceState == nil {
+					resp.Diagnostics.AddError(
+						"Unsupported source state",
+						fmt.Sprintf("Unable to move hpcr_contract_encrypted state of schema version %d", req.SourceSchemaVersion),
+					)
+					return
+				}
+
+				var source ContractEncryptedResourceModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// Keep the ID and the outputs, the next apply adds the expiry to the contract in place
+				target := ContractEncryptedContractExpiryResourceModel{
+					ID:         source.ID,
+					Contract:   source.Contract,
+					Platform:   source.Platform,
+					Version:    source.Version,
+					Cert:       source.Cert,
+					PrivKey:    source.PrivKey,
+					Password:   source.Password,
+					ExpiryDays: types.Int64Null(),
+					CaCert:     types.StringNull(),
+					CaKey:      types.StringNull(),
+					CsrParams:  types.MapNull(types.StringType),
+					Csr:        types.StringNull(),
+					Rendered:   source.Rendered,
+					Sha256In:   source.Sha256In,
+					Sha256Out:  source.Sha256Out,
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &target)...)
+			},
+		},
+	}
+}
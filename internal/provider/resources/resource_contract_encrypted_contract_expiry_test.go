@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 func TestContractEncryptedContractExpiryResource_Metadata(t *testing.T) {
@@ -156,3 +157,20 @@ func TestContractEncryptedContractExpiryResource_Delete(t *testing.T) {
 		t.Error("Delete should not produce errors")
 	}
 }
+
+func TestContractEncryptedContractExpiryResource_PlatformValidators(t *testing.T) {
+	r := NewContractEncryptedContractExpiryResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify platform and version are validated at plan time
+	for _, attr := range []string{"platform", "version"} {
+		stringAttr, ok := resp.Schema.Attributes[attr].(schema.StringAttribute)
+		if !ok || len(stringAttr.Validators) == 0 {
+			t.Errorf("Expected '%s' attribute to have validators", attr)
+		}
+	}
+}
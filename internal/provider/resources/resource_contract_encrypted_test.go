@@ -16,9 +16,20 @@ package resources
 
 import (
 	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
 )
 
 func TestContractEncryptedResource_Metadata(t *testing.T) {
@@ -147,3 +158,123 @@ func TestContractEncryptedResource_Delete(t *testing.T) {
 		t.Error("Delete should not produce errors")
 	}
 }
+
+func TestContractEncryptedResource_PlatformValidators(t *testing.T) {
+	r := NewContractEncryptedResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify platform and version are validated at plan time
+	for _, attr := range []string{"platform", "version"} {
+		stringAttr, ok := resp.Schema.Attributes[attr].(schema.StringAttribute)
+		if !ok || len(stringAttr.Validators) == 0 {
+			t.Errorf("Expected '%s' attribute to have validators", attr)
+		}
+	}
+}
+
+// verifyContractSignature checks that envWorkloadSignature of a rendered
+// contract covers its workload and env sections and returns the sections.
+func verifyContractSignature(t *testing.T, rendered, privKey string) map[string]string {
+	t.Helper()
+
+	var sections map[string]string
+	if err := yaml.Unmarshal([]byte(rendered), &sections); err != nil {
+		t.Fatalf("Failed to parse rendered contract: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(privKey))
+	if block == nil {
+		t.Fatal("Failed to decode private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(sections["envWorkloadSignature"])
+	if err != nil {
+		t.Fatalf("Failed to decode signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(sections["workload"] + sections["env"]))
+	if err := rsa.VerifyPKCS1v15(&parsed.(*rsa.PrivateKey).PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("Expected envWorkloadSignature to cover the final workload and env, verification failed: %v", err)
+	}
+
+	return sections
+}
+
+func TestContractEncryptedResource_CreatePreEncryptedWorkload(t *testing.T) {
+	privKey, err := common.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey() failed: %v", err)
+	}
+	workload := "hyper-protect-basic.aXN2.d29ya2xvYWQ="
+
+	var data ContractEncryptedResourceModel
+	testCreate(t, NewContractEncryptedResource(), ContractEncryptedResourceModel{
+		Contract: types.StringValue("workload: " + workload + "\nenv:\n  type: env\n"),
+		PrivKey:  types.StringValue(privKey),
+	}, &data)
+
+	sections := verifyContractSignature(t, data.Rendered.ValueString(), privKey)
+
+	// The pre-encrypted workload is not encrypted again
+	if sections["workload"] != workload {
+		t.Errorf("Expected workload to pass through, got '%s'", sections["workload"])
+	}
+	if !common.IsEncryptedSection(sections["env"]) {
+		t.Errorf("Expected env to be encrypted, got '%s'", sections["env"])
+	}
+	if data.Sha256Out.ValueString() != common.GenerateSha256(data.Rendered.ValueString()) {
+		t.Error("Expected sha256_out to refer to the rendered contract")
+	}
+}
+
+func TestContractEncryptedResource_CreatePreEncryptedEnvWithoutKey(t *testing.T) {
+	r := NewContractEncryptedResource()
+	plan, state := testPlan(t, r, ContractEncryptedResourceModel{
+		Contract: types.StringValue("workload:\n  type: workload\nenv: hyper-protect-basic.aXN2.ZW52\n"),
+	})
+
+	resp := &resource.CreateResponse{State: state}
+	r.Create(context.TODO(), resource.CreateRequest{Plan: plan}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for an encrypted env section without privkey")
+	}
+}
+
+func TestEncryptedContractSections(t *testing.T) {
+	tests := []struct {
+		name              string
+		contract          string
+		privKeyConfigured bool
+		allowEncrypted    bool
+		want              int
+		wantErr           bool
+	}{
+		{"plaintext", "workload: |\n    type: workload\nenv: |\n    type: env\n", false, false, 0, false},
+		{"encrypted workload", "workload: hyper-protect-basic.a.b\nenv: |\n    type: env\n", false, true, 1, false},
+		{"encrypted env without key", "workload: hyper-protect-basic.a.b\nenv: hyper-protect-basic.c.d\n", false, true, 0, true},
+		{"encrypted env with key", "workload: hyper-protect-basic.a.b\nenv: hyper-protect-basic.c.d\n", true, true, 2, false},
+		{"encrypted not allowed", "workload: hyper-protect-basic.a.b\nenv: |\n    type: env\n", true, false, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections, diags := encryptedContractSections(tt.contract, tt.privKeyConfigured, tt.allowEncrypted)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("encryptedContractSections() error = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if len(sections) != tt.want {
+				t.Errorf("Expected %d encrypted sections, got %v", tt.want, sections)
+			}
+		})
+	}
+}
@@ -0,0 +1,543 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
+)
+
+var _ resource.Resource = &ContractSetResource{}
+var _ resource.ResourceWithConfigure = &ContractSetResource{}
+var _ resource.ResourceWithImportState = &ContractSetResource{}
+
+func NewContractSetResource() resource.Resource {
+	return &ContractSetResource{}
+}
+
+type ContractSetResource struct {
+	idMode string
+}
+
+type ContractSetResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Contract    types.String `tfsdk:"contract"`
+	Overrides   types.Map    `tfsdk:"overrides"`
+	Platform    types.String `tfsdk:"platform"`
+	Version     types.String `tfsdk:"version"`
+	Cert        types.String `tfsdk:"cert"`
+	PrivKey     types.String `tfsdk:"privkey"`
+	Password    types.String `tfsdk:"password"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	Rendered    types.Map    `tfsdk:"rendered"`
+	Sha256In    types.Map    `tfsdk:"sha256_in"`
+	Sha256Out   types.Map    `tfsdk:"sha256_out"`
+}
+
+// contractSetResult is the rendered contract of a single instance of the set.
+type contractSetResult struct {
+	rendered  string
+	sha256In  string
+	sha256Out string
+}
+
+func (r *ContractSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contract_set"
+}
+
+func (r *ContractSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates encrypted and signed user data fields for a set of instances from a base HPCR contract and per-instance env overrides. All contracts are signed by the same key.",
+		Description:         "Generates encrypted and signed user data fields for a set of instances from a base HPCR contract and per-instance env overrides.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"contract": schema.StringAttribute{
+				MarkdownDescription: "YAML serialization of the base contract",
+				Description:         "YAML serialization of the base contract",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"overrides": schema.MapAttribute{
+				MarkdownDescription: "Map of instance name to a YAML or JSON mapping that is deep merged into the `env` section of the base contract. Use an empty string for an instance without overrides.",
+				Description:         "Map of instance name to a YAML or JSON mapping that is deep merged into the env section of the base contract",
+				Required:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"platform": schema.StringAttribute{
+				MarkdownDescription: "Hyper Protect platform where these contracts will be deployed. Defaults to hpvs",
+				Description:         "Hyper Protect platform where these contracts will be deployed",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Platform(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the Hyper Protect Platform",
+				Description:         "Version of the Hyper Protect Platform",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.PlatformVersion(path.Root("platform")),
+				},
+			},
+			"cert": schema.StringAttribute{
+				MarkdownDescription: "Certificate used to encrypt the contracts, in PEM format. Defaults to the latest HPCR image certificate if not specified.",
+				Description:         "Certificate used to encrypt the contracts, in PEM format",
+				Optional:            true,
+			},
+			"privkey": schema.StringAttribute{
+				MarkdownDescription: "Private key used to sign the contracts. If omitted, a temporary signing key is created and all contracts are generated again on every change.",
+				Description:         "Private key used to sign the contracts",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password used to decrypt the private key",
+				Description:         "Password used to decrypt the private key",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of contracts encrypted concurrently. Defaults to the number of CPUs.",
+				Description:         "Maximum number of contracts encrypted concurrently",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"rendered": schema.MapAttribute{
+				MarkdownDescription: "Map of instance name to the rendered contract",
+				Description:         "Map of instance name to the rendered contract",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"sha256_in": schema.MapAttribute{
+				MarkdownDescription: "Map of instance name to the SHA256 of the merged contract",
+				Description:         "Map of instance name to the SHA256 of the merged contract",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"sha256_out": schema.MapAttribute{
+				MarkdownDescription: "Map of instance name to the SHA256 of the rendered contract",
+				Description:         "Map of instance name to the SHA256 of the rendered contract",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (r *ContractSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
+func (r *ContractSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContractSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := contractSetInputFrom(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, err := renderContractSet(input.names, input.parallelism, func(name string) (contractSetResult, error) {
+		return renderContract(input.refinedContracts[name], input.platform, input.version, input.cert, input.privKey, input.password)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create signed encrypted contract",
+			fmt.Sprintf("Error creating signed encrypted contract: %s", err.Error()),
+		)
+		return
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	idParts := []string{}
+	for _, name := range input.names {
+		idParts = append(idParts, name, results[name].sha256In)
+	}
+	idParts = append(idParts, input.platform, input.version, common.GenerateSha256(input.cert))
+	id, err := common.ResourceID(r.idMode, idParts...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields
+	data.ID = types.StringValue(id)
+	resp.Diagnostics.Append(setContractSetResults(ctx, &data, results)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContractSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContractSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContractSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ContractSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Existing contracts can only be kept if they are signed by the configured key
+	// and encrypted for the same target, otherwise all contracts are generated again
+	previous := map[string]contractSetResult{}
+	if data.PrivKey.ValueString() != "" && data.PrivKey.Equal(state.PrivKey) && data.Password.Equal(state.Password) &&
+		data.Platform.Equal(state.Platform) && data.Version.Equal(state.Version) && data.Cert.Equal(state.Cert) {
+		var diags diag.Diagnostics
+		previous, diags = contractSetResults(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	input, diags := contractSetInputFrom(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, err := renderContractSet(input.names, input.parallelism, func(name string) (contractSetResult, error) {
+		// Keep the contract of an instance whose merged contract did not change
+		if result, ok := previous[name]; ok && result.sha256In == common.GenerateSha256(input.refinedContracts[name]) {
+			return result, nil
+		}
+		return renderContract(input.refinedContracts[name], input.platform, input.version, input.cert, input.privKey, input.password)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create signed encrypted contract",
+			fmt.Sprintf("Error creating signed encrypted contract: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields (keep the existing ID)
+	resp.Diagnostics.Append(setContractSetResults(ctx, &data, results)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContractSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No-op
+}
+
+func (r *ContractSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, ok := splitImportID(req.ID, "contract_set", 1)
+	var names []string
+	if ok {
+		names = strings.Split(parts[0], ",")
+		ok = !slices.Contains(names, "")
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form contract_set:<instance>[,<instance>...], got '%s'", req.ID),
+		)
+		return
+	}
+	sort.Strings(names)
+
+	id, err := common.ResourceID(r.idMode, names...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// The signing key is not part of the imported state, so the next apply generates the contracts of all instances again
+	data := ContractSetResourceModel{
+		ID:          types.StringValue(id),
+		Contract:    types.StringNull(),
+		Overrides:   types.MapNull(types.StringType),
+		Platform:    types.StringNull(),
+		Version:     types.StringNull(),
+		Cert:        types.StringNull(),
+		PrivKey:     types.StringNull(),
+		Password:    types.StringNull(),
+		Parallelism: types.Int64Null(),
+		Rendered:    types.MapNull(types.StringType),
+		Sha256In:    types.MapNull(types.StringType),
+		Sha256Out:   types.MapNull(types.StringType),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// contractSetInput holds the settings shared by all contracts of a set and the
+// refined contract of each instance.
+type contractSetInput struct {
+	platform         string
+	version          string
+	cert             string
+	privKey          string
+	password         string
+	parallelism      int
+	names            []string
+	refinedContracts map[string]string
+}
+
+// contractSetInputFrom reads the settings of the contract set from the plan,
+// checks the encryption certificate, generates a signing key if none is
+// configured and refines the contract of each instance.
+func contractSetInputFrom(ctx context.Context, data *ContractSetResourceModel) (contractSetInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	input := contractSetInput{
+		platform:    data.Platform.ValueString(),
+		version:     data.Version.ValueString(),
+		privKey:     data.PrivKey.ValueString(),
+		password:    data.Password.ValueString(),
+		parallelism: runtime.NumCPU(),
+	}
+	if !data.Parallelism.IsNull() {
+		input.parallelism = int(data.Parallelism.ValueInt64())
+	}
+
+	overrides := map[string]string{}
+	diags.Append(data.Overrides.ElementsAs(ctx, &overrides, false)...)
+	if diags.HasError() {
+		return input, diags
+	}
+
+	if !data.Cert.IsNull() && !data.Cert.IsUnknown() {
+		input.cert = data.Cert.ValueString()
+
+		// check expiry of the encryption certificate
+		expiryInfo, err := certificate.HpcrValidateEncryptionCertificate(input.cert)
+		if err != nil {
+			diags.AddError(
+				"Fail to encrypt contracts",
+				fmt.Sprintf("Encryption certificate has expired: %s", err.Error()),
+			)
+			return input, diags
+		}
+
+		diags.AddWarning(
+			"Encryption certificate validity",
+			expiryInfo,
+		)
+	}
+
+	// Generate a single private key for all instances if not provided
+	if input.privKey == "" {
+		generatedKey, err := common.GeneratePrivateKey()
+		if err != nil {
+			diags.AddError(
+				"Failed to generate private key",
+				fmt.Sprintf("Error generating private key: %s", err.Error()),
+			)
+			return input, diags
+		}
+		input.privKey = generatedKey
+	}
+
+	names, refinedContracts, err := refineContractSet(data.Contract.ValueString(), overrides, data.PrivKey.ValueString() != "")
+	if err != nil {
+		diags.AddError(
+			"Failed to refine contract",
+			err.Error(),
+		)
+		return input, diags
+	}
+	input.names = names
+	input.refinedContracts = refinedContracts
+
+	return input, diags
+}
+
+// refineContractSet merges the overrides of each instance into the env section
+// of the contract and refines the result. It returns the sorted instance names
+// and the refined contract of each instance.
+func refineContractSet(contractYAML string, overrides map[string]string, hasPrivKey bool) ([]string, map[string]string, error) {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	refinedContracts := make(map[string]string, len(names))
+	for _, name := range names {
+		merged, err := common.MergeContractEnv(contractYAML, overrides[name])
+		if err != nil {
+			return nil, nil, fmt.Errorf("error merging overrides of instance %q: %v", name, err)
+		}
+
+		refinedContract, err := common.RefineContract(merged)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error refining contract of instance %q: %v", name, err)
+		}
+
+		encryptedSections, err := common.EncryptedSections(refinedContract)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading contract sections of instance %q: %v", name, err)
+		}
+		if slices.Contains(encryptedSections, "env") && !hasPrivKey {
+			return nil, nil, fmt.Errorf("the env section of instance %q is already encrypted, so 'privkey' must be the key whose public key was embedded as signingKey", name)
+		}
+
+		refinedContracts[name] = refinedContract
+	}
+
+	return names, refinedContracts, nil
+}
+
+// renderContract signs and encrypts a refined contract. Sections that are
+// already encrypted are kept as they are.
+func renderContract(refinedContract, platform, version, cert, privKey, password string) (contractSetResult, error) {
+	encryptedSections, err := common.EncryptedSections(refinedContract)
+	if err != nil {
+		return contractSetResult{}, err
+	}
+
+	signedContract, inputHash, outputHash, err := signContract(refinedContract, encryptedSections, platform, version, cert, privKey, password)
+	if err != nil {
+		return contractSetResult{}, err
+	}
+	return contractSetResult{rendered: signedContract, sha256In: inputHash, sha256Out: outputHash}, nil
+}
+
+// renderContractSet renders the contract of each instance with at most
+// parallelism renders running concurrently. If rendering fails, the error of
+// the first failing instance in the order of names is returned.
+func renderContractSet(names []string, parallelism int, render func(name string) (contractSetResult, error)) (map[string]contractSetResult, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([]contractSetResult, len(names))
+	errs := make([]error, len(names))
+	semaphore := make(chan struct{}, parallelism)
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i], errs[i] = render(name)
+		}()
+	}
+	wg.Wait()
+
+	resultMap := make(map[string]contractSetResult, len(names))
+	for i, name := range names {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("instance %q: %w", name, errs[i])
+		}
+		resultMap[name] = results[i]
+	}
+
+	return resultMap, nil
+}
+
+// contractSetResults reads the rendered contracts of each instance from the
+// computed maps of the model.
+func contractSetResults(ctx context.Context, data *ContractSetResourceModel) (map[string]contractSetResult, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rendered := map[string]string{}
+	sha256In := map[string]string{}
+	sha256Out := map[string]string{}
+	if !data.Rendered.IsNull() {
+		diags.Append(data.Rendered.ElementsAs(ctx, &rendered, false)...)
+	}
+	if !data.Sha256In.IsNull() {
+		diags.Append(data.Sha256In.ElementsAs(ctx, &sha256In, false)...)
+	}
+	if !data.Sha256Out.IsNull() {
+		diags.Append(data.Sha256Out.ElementsAs(ctx, &sha256Out, false)...)
+	}
+
+	results := make(map[string]contractSetResult, len(rendered))
+	for name, value := range rendered {
+		results[name] = contractSetResult{rendered: value, sha256In: sha256In[name], sha256Out: sha256Out[name]}
+	}
+
+	return results, diags
+}
+
+// setContractSetResults sets the computed maps of the model from the rendered
+// contracts of each instance.
+func setContractSetResults(ctx context.Context, data *ContractSetResourceModel, results map[string]contractSetResult) diag.Diagnostics {
+	rendered := make(map[string]string, len(results))
+	sha256In := make(map[string]string, len(results))
+	sha256Out := make(map[string]string, len(results))
+	for name, result := range results {
+		rendered[name] = result.rendered
+		sha256In[name] = result.sha256In
+		sha256Out[name] = result.sha256Out
+	}
+
+	var diags, d diag.Diagnostics
+	data.Rendered, d = types.MapValueFrom(ctx, types.StringType, rendered)
+	diags.Append(d...)
+	data.Sha256In, d = types.MapValueFrom(ctx, types.StringType, sha256In)
+	diags.Append(d...)
+	data.Sha256Out, d = types.MapValueFrom(ctx, types.StringType, sha256Out)
+	diags.Append(d...)
+
+	return diags
+}
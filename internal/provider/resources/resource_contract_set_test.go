@@ -0,0 +1,303 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+func TestContractSetResource_Metadata(t *testing.T) {
+	r := NewContractSetResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_contract_set" {
+		t.Errorf("Expected TypeName to be 'hpcr_contract_set', got '%s'", resp.TypeName)
+	}
+}
+
+func TestContractSetResource_Schema(t *testing.T) {
+	r := NewContractSetResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	// Verify required attributes
+	for _, attr := range []string{"contract", "overrides"} {
+		if !resp.Schema.Attributes[attr].IsRequired() {
+			t.Errorf("Expected '%s' attribute to be required", attr)
+		}
+	}
+
+	// Verify optional attributes
+	for _, attr := range []string{"platform", "version", "cert", "privkey", "password", "parallelism"} {
+		if !resp.Schema.Attributes[attr].IsOptional() {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
+	// Verify that parallelism is validated at plan time
+	if attr := resp.Schema.Attributes["parallelism"].(schema.Int64Attribute); len(attr.Validators) == 0 {
+		t.Error("Expected 'parallelism' attribute to have a validator")
+	}
+
+	// Verify computed attributes
+	for _, attr := range []string{"id", "rendered", "sha256_in", "sha256_out"} {
+		if !resp.Schema.Attributes[attr].IsComputed() {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+
+	// Verify sensitive attributes
+	for _, attr := range []string{"contract", "overrides", "privkey", "password"} {
+		if !resp.Schema.Attributes[attr].IsSensitive() {
+			t.Errorf("Expected '%s' attribute to be sensitive", attr)
+		}
+	}
+}
+
+func TestNewContractSetResource(t *testing.T) {
+	r := NewContractSetResource()
+	if r == nil {
+		t.Fatal("NewContractSetResource should not return nil")
+	}
+
+	// Verify it implements the Resource interface
+	var _ resource.Resource = &ContractSetResource{}
+}
+
+func TestContractSetResource_SchemaDescriptions(t *testing.T) {
+	r := NewContractSetResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	for name, attr := range resp.Schema.Attributes {
+		if attr.GetDescription() == "" && attr.GetMarkdownDescription() == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
+
+func TestRefineContractSet(t *testing.T) {
+	contractYAML := "env:\n  type: env\n  env:\n    LOG_LEVEL: info\n"
+	overrides := map[string]string{
+		"node-2": `{"env": {"HOSTNAME": "node-2"}}`,
+		"node-1": "env:\n  HOSTNAME: node-1\n",
+		"node-3": "",
+	}
+
+	names, refinedContracts, err := refineContractSet(contractYAML, overrides, false)
+	if err != nil {
+		t.Fatalf("refineContractSet() failed: %v", err)
+	}
+
+	if fmt.Sprint(names) != "[node-1 node-2 node-3]" {
+		t.Errorf("Expected sorted names, got %v", names)
+	}
+	if refinedContracts["node-1"] == refinedContracts["node-2"] {
+		t.Error("Expected different contracts for instances with different overrides")
+	}
+	if refinedContracts["node-3"] == "" {
+		t.Error("Expected a contract for the instance without overrides")
+	}
+}
+
+func TestRefineContractSet_EncryptedEnv(t *testing.T) {
+	contractYAML := "env: hyper-protect-basic.a2V5.ZGF0YQ==\n"
+
+	if _, _, err := refineContractSet(contractYAML, map[string]string{"node-1": ""}, false); err == nil {
+		t.Error("Expected an error for an encrypted env section without privkey")
+	}
+	if _, _, err := refineContractSet(contractYAML, map[string]string{"node-1": "type: env\n"}, true); err == nil {
+		t.Error("Expected an error for overrides of an encrypted env section")
+	}
+}
+
+func TestRenderContractSet(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+
+	var running, maxRunning int32
+	results, err := renderContractSet(names, 2, func(name string) (contractSetResult, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+		return contractSetResult{rendered: "rendered-" + name}, nil
+	})
+	if err != nil {
+		t.Fatalf("renderContractSet() failed: %v", err)
+	}
+
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 concurrent renders, got %d", maxRunning)
+	}
+	for _, name := range names {
+		if results[name].rendered != "rendered-"+name {
+			t.Errorf("Expected rendered-%s, got %q", name, results[name].rendered)
+		}
+	}
+}
+
+func TestRenderContractSet_Error(t *testing.T) {
+	_, err := renderContractSet([]string{"a", "b", "c"}, 3, func(name string) (contractSetResult, error) {
+		if name == "a" {
+			return contractSetResult{}, nil
+		}
+		return contractSetResult{}, fmt.Errorf("failed %s", name)
+	})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if err.Error() != `instance "b": failed b` {
+		t.Errorf("Expected the error of the first failing instance, got %q", err.Error())
+	}
+}
+
+func TestContractSetResource_Delete(t *testing.T) {
+	r := &ContractSetResource{}
+
+	req := resource.DeleteRequest{}
+	resp := &resource.DeleteResponse{}
+
+	r.Delete(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Delete should not produce errors")
+	}
+}
+
+func TestContractSetResource_UpdateKeepsUnchangedInstances(t *testing.T) {
+	privKey, err := common.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey() failed: %v", err)
+	}
+
+	model := ContractSetResourceModel{
+		ID:          types.StringUnknown(),
+		Contract:    types.StringValue("env:\n  type: env\n  env:\n    LOG_LEVEL: info\n"),
+		Overrides:   testStringMap(t, map[string]string{"node-1": "env:\n  HOSTNAME: node-1\n", "node-2": "env:\n  HOSTNAME: node-2\n"}),
+		Platform:    types.StringNull(),
+		Version:     types.StringNull(),
+		Cert:        types.StringNull(),
+		PrivKey:     types.StringValue(privKey),
+		Password:    types.StringNull(),
+		Parallelism: types.Int64Null(),
+		Rendered:    types.MapUnknown(types.StringType),
+		Sha256In:    types.MapUnknown(types.StringType),
+		Sha256Out:   types.MapUnknown(types.StringType),
+	}
+
+	var created ContractSetResourceModel
+	testCreate(t, NewContractSetResource(), model, &created)
+	createdResults, diags := contractSetResults(context.TODO(), &created)
+	if diags.HasError() {
+		t.Fatalf("Failed to read results: %v", diags)
+	}
+
+	// Mark the rendered contract of node-2 to detect whether it is rendered again
+	createdResults["node-2"] = contractSetResult{rendered: "kept", sha256In: createdResults["node-2"].sha256In, sha256Out: common.GenerateSha256("kept")}
+	if diags := setContractSetResults(context.TODO(), &created, createdResults); diags.HasError() {
+		t.Fatalf("Failed to set results: %v", diags)
+	}
+
+	model.ID = created.ID
+	model.Overrides = testStringMap(t, map[string]string{"node-1": "env:\n  HOSTNAME: node-1a\n", "node-2": "env:\n  HOSTNAME: node-2\n"})
+
+	var updated ContractSetResourceModel
+	testUpdate(t, NewContractSetResource(), created, model, &updated)
+	updatedResults, diags := contractSetResults(context.TODO(), &updated)
+	if diags.HasError() {
+		t.Fatalf("Failed to read results: %v", diags)
+	}
+
+	if updatedResults["node-2"].rendered != "kept" {
+		t.Error("Expected the contract of the unchanged instance to be kept")
+	}
+	if updatedResults["node-1"].rendered == createdResults["node-1"].rendered || updatedResults["node-1"].sha256In == createdResults["node-1"].sha256In {
+		t.Error("Expected the contract of the changed instance to be rendered again")
+	}
+}
+
+func TestContractSetResource_ImportState(t *testing.T) {
+	r := &ContractSetResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.TODO(), resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(context.TODO(), resource.ImportStateRequest{ID: "hpcr_contract_set:node-2,node-1"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState failed: %v", resp.Diagnostics)
+	}
+
+	var data ContractSetResourceModel
+	if diags := resp.State.Get(context.TODO(), &data); diags.HasError() {
+		t.Fatalf("Failed to read state: %v", diags)
+	}
+	if data.ID.ValueString() == "" || !data.Rendered.IsNull() {
+		t.Errorf("Expected an ID and no rendered contracts, got %+v", data)
+	}
+
+	resp = &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(context.TODO(), resource.ImportStateRequest{ID: "contract_set:node-1,,node-2"}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for an empty instance name")
+	}
+}
+
+func testStringMap(t *testing.T, values map[string]string) types.Map {
+	t.Helper()
+
+	value, diags := types.MapValueFrom(context.TODO(), types.StringType, values)
+	if diags.HasError() {
+		t.Fatalf("Failed to build map: %v", diags)
+	}
+	return value
+}
@@ -16,13 +16,15 @@ package resources
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
@@ -30,19 +32,38 @@ import (
 )
 
 var _ resource.Resource = &JSONResource{}
+var _ resource.ResourceWithConfigure = &JSONResource{}
+var _ resource.ResourceWithImportState = &JSONResource{}
+var _ resource.ResourceWithUpgradeState = &JSONResource{}
+var _ resource.ResourceWithValidateConfig = &JSONResource{}
 
 func NewJSONResource() resource.Resource {
 	return &JSONResource{}
 }
 
-type JSONResource struct{}
+type JSONResource struct {
+	idMode string
+}
 
 type JSONResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	JSON      types.String `tfsdk:"json"`
-	Rendered  types.String `tfsdk:"rendered"`
-	Sha256In  types.String `tfsdk:"sha256_in"`
-	Sha256Out types.String `tfsdk:"sha256_out"`
+	ID               types.String  `tfsdk:"id"`
+	JSON             types.String  `tfsdk:"json"`
+	Value            types.Dynamic `tfsdk:"value"`
+	Compression      types.String  `tfsdk:"compression"`
+	Rendered         types.String  `tfsdk:"rendered"`
+	Sha256In         types.String  `tfsdk:"sha256_in"`
+	Sha256Out        types.String  `tfsdk:"sha256_out"`
+	CompressionRatio types.Float64 `tfsdk:"compression_ratio"`
+}
+
+// jsonResourceAttrTypesV0 are the attribute types of schema version 0,
+// before value, compression and compression_ratio were added.
+var jsonResourceAttrTypesV0 = map[string]attr.Type{
+	"id":         types.StringType,
+	"json":       types.StringType,
+	"rendered":   types.StringType,
+	"sha256_in":  types.StringType,
+	"sha256_out": types.StringType,
 }
 
 func (r *JSONResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +72,8 @@ func (r *JSONResource) Metadata(ctx context.Context, req resource.MetadataReques
 
 func (r *JSONResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
+
 		MarkdownDescription: "Generates a base64 encoded token from the JSON serialization of the input.",
 		Description:         "Generates a base64 encoded token from the JSON serialization of the input.",
 
@@ -63,11 +86,25 @@ func (r *JSONResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"json": schema.StringAttribute{
-				MarkdownDescription: "JSON Document to archive",
+				MarkdownDescription: "JSON Document to archive. Exactly one of `json` or `value` must be provided.",
 				Description:         "JSON Document to archive",
-				Required:            true,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"value": schema.DynamicAttribute{
+				MarkdownDescription: "Any Terraform value (object, list or scalar) to serialize as canonical JSON with sorted keys. Exactly one of `json` or `value` must be provided.",
+				Description:         "Any Terraform value to serialize as canonical JSON",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"compression": schema.StringAttribute{
+				MarkdownDescription: "Compression applied to the JSON document before it is encoded, one of `none` or `gzip`. Defaults to `none`.",
+				Description:         "Compression applied to the JSON document before it is encoded, one of none or gzip",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.CompressionNone, common.CompressionGzip),
+				},
+			},
 			"rendered": schema.StringAttribute{
 				MarkdownDescription: "Rendered output of the resource",
 				Description:         "Rendered output of the resource",
@@ -83,10 +120,29 @@ func (r *JSONResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description:         "SHA256 of the output",
 				Computed:            true,
 			},
+			"compression_ratio": schema.Float64Attribute{
+				MarkdownDescription: "Ratio of the uncompressed to the compressed input size",
+				Description:         "Ratio of the uncompressed to the compressed input size",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+func (r *JSONResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data JSONResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateJSONInput(data.JSON, data.Value)...)
+}
+
+func (r *JSONResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
 func (r *JSONResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data JSONResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -94,27 +150,39 @@ func (r *JSONResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Get the input JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(data.JSON.ValueString()), &jsonData); err != nil {
+	// Get the input JSON in its canonical form
+	jsonStr, err := canonicalJSON(data.JSON, data.Value)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to decode JSON",
-			fmt.Sprintf("Error decoding JSON: %s", err.Error()),
+			"Failed to serialize JSON",
+			fmt.Sprintf("Error serializing JSON: %s", err.Error()),
 		)
 		return
 	}
+	jsonBytes := []byte(jsonStr)
+
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
 
-	jsonBytes, err := json.Marshal(jsonData)
+	payload, err := common.Compress(jsonBytes, compression)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to marshal JSON",
-			fmt.Sprintf("Error marshaling JSON: %s", err.Error()),
+			"Failed to compress JSON",
+			fmt.Sprintf("Error compressing JSON: %s", err.Error()),
 		)
 		return
 	}
 
-	// Encode JSON using the contract-go library
-	encoded, inputHash, outputHash, err := contract.HpcrJson(string(jsonBytes))
+	// Encode JSON using the contract-go library, a compressed payload is no
+	// longer JSON and is encoded as text
+	var encoded, inputHash, outputHash string
+	if compression == common.CompressionNone {
+		encoded, inputHash, outputHash, err = contract.HpcrJson(string(payload))
+	} else {
+		encoded, inputHash, outputHash, err = contract.HpcrText(string(payload))
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encode JSON",
@@ -123,8 +191,13 @@ func (r *JSONResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// sha256_in always refers to the uncompressed JSON document
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(string(jsonBytes))
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -138,6 +211,7 @@ func (r *JSONResource) Create(ctx context.Context, req resource.CreateRequest, r
 	data.Rendered = types.StringValue(encoded)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(jsonBytes), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -158,27 +232,55 @@ func (r *JSONResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// Get the input JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(data.JSON.ValueString()), &jsonData); err != nil {
+	// Get the input JSON in its canonical form
+	jsonStr, err := canonicalJSON(data.JSON, data.Value)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to decode JSON",
-			fmt.Sprintf("Error decoding JSON: %s", err.Error()),
+			"Failed to serialize JSON",
+			fmt.Sprintf("Error serializing JSON: %s", err.Error()),
 		)
 		return
 	}
+	jsonBytes := []byte(jsonStr)
 
-	jsonBytes, err := json.Marshal(jsonData)
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
+
+	payload, err := common.Compress(jsonBytes, compression)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to marshal JSON",
-			fmt.Sprintf("Error marshaling JSON: %s", err.Error()),
+			"Failed to compress JSON",
+			fmt.Sprintf("Error compressing JSON: %s", err.Error()),
 		)
 		return
 	}
 
-	// Encode JSON using the contract-go library
-	encoded, inputHash, outputHash, err := contract.HpcrJson(string(jsonBytes))
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(string(jsonBytes)))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(string(jsonBytes)))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(jsonBytes), len(payload)))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Encode JSON using the contract-go library, a compressed payload is no
+	// longer JSON and is encoded as text
+	var encoded, inputHash, outputHash string
+	if compression == common.CompressionNone {
+		encoded, inputHash, outputHash, err = contract.HpcrJson(string(payload))
+	} else {
+		encoded, inputHash, outputHash, err = contract.HpcrText(string(payload))
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encode JSON",
@@ -187,10 +289,16 @@ func (r *JSONResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// sha256_in always refers to the uncompressed JSON document
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(string(jsonBytes))
+	}
+
 	// Set the computed fields (keep the existing ID)
 	data.Rendered = types.StringValue(encoded)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(jsonBytes), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -198,3 +306,13 @@ func (r *JSONResource) Update(ctx context.Context, req resource.UpdateRequest, r
 func (r *JSONResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op
 }
+
+func (r *JSONResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "json", r.idMode, req, resp)
+}
+
+func (r *JSONResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: addedAttributesUpgrader(jsonResourceAttrTypesV0),
+	}
+}
@@ -16,37 +16,63 @@ package resources
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
 )
 
 var _ resource.Resource = &JSONEncryptedResource{}
+var _ resource.ResourceWithConfigure = &JSONEncryptedResource{}
+var _ resource.ResourceWithImportState = &JSONEncryptedResource{}
+var _ resource.ResourceWithUpgradeState = &JSONEncryptedResource{}
+var _ resource.ResourceWithValidateConfig = &JSONEncryptedResource{}
 
 func NewJSONEncryptedResource() resource.Resource {
 	return &JSONEncryptedResource{}
 }
 
-type JSONEncryptedResource struct{}
+type JSONEncryptedResource struct {
+	idMode string
+}
 
 type JSONEncryptedResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	JSON      types.String `tfsdk:"json"`
-	Cert      types.String `tfsdk:"cert"`
-	Platform  types.String `tfsdk:"platform"`
-	Version   types.String `tfsdk:"version"`
-	Rendered  types.String `tfsdk:"rendered"`
-	Sha256In  types.String `tfsdk:"sha256_in"`
-	Sha256Out types.String `tfsdk:"sha256_out"`
+	ID               types.String  `tfsdk:"id"`
+	JSON             types.String  `tfsdk:"json"`
+	Value            types.Dynamic `tfsdk:"value"`
+	Compression      types.String  `tfsdk:"compression"`
+	Cert             types.String  `tfsdk:"cert"`
+	Platform         types.String  `tfsdk:"platform"`
+	Version          types.String  `tfsdk:"version"`
+	Rendered         types.String  `tfsdk:"rendered"`
+	Sha256In         types.String  `tfsdk:"sha256_in"`
+	Sha256Out        types.String  `tfsdk:"sha256_out"`
+	CompressionRatio types.Float64 `tfsdk:"compression_ratio"`
+}
+
+// jsonEncryptedResourceAttrTypesV0 are the attribute types of schema version 0,
+// before value, compression and compression_ratio were added.
+var jsonEncryptedResourceAttrTypesV0 = map[string]attr.Type{
+	"id":         types.StringType,
+	"json":       types.StringType,
+	"cert":       types.StringType,
+	"platform":   types.StringType,
+	"version":    types.StringType,
+	"rendered":   types.StringType,
+	"sha256_in":  types.StringType,
+	"sha256_out": types.StringType,
 }
 
 func (r *JSONEncryptedResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +81,8 @@ func (r *JSONEncryptedResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *JSONEncryptedResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
+
 		MarkdownDescription: "Generates an encrypted token from the JSON serialization of the input.",
 		Description:         "Generates an encrypted token from the JSON serialization of the input.",
 
@@ -67,11 +95,26 @@ func (r *JSONEncryptedResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"json": schema.StringAttribute{
-				MarkdownDescription: "JSON Document to archive",
+				MarkdownDescription: "JSON Document to archive. Exactly one of `json` or `value` must be provided.",
 				Description:         "JSON Document to archive",
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"value": schema.DynamicAttribute{
+				MarkdownDescription: "Any Terraform value (object, list or scalar) to serialize as canonical JSON with sorted keys. Exactly one of `json` or `value` must be provided.",
+				Description:         "Any Terraform value to serialize as canonical JSON",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"compression": schema.StringAttribute{
+				MarkdownDescription: "Compression applied to the JSON document before it is encrypted, one of `none` or `gzip`. Defaults to `none`. " +
+					"Compressed payloads are rejected for platforms and versions whose runtime is not documented to decompress them.",
+				Description: "Compression applied to the JSON document before it is encrypted, one of none or gzip",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.CompressionNone, common.CompressionGzip),
+				},
+			},
 			"cert": schema.StringAttribute{
 				MarkdownDescription: "Certificate used to encrypt the JSON document, in PEM format. Defaults to the latest HPCR image certificate if not specified.",
 				Description:         "Certificate used to encrypt the JSON document, in PEM format",
@@ -81,11 +124,17 @@ func (r *JSONEncryptedResource) Schema(ctx context.Context, req resource.SchemaR
 				MarkdownDescription: "Hyper Protect platform where this contract will be deployed. Defaults to hpvs",
 				Description:         "Hyper Protect platform where this contract will be deployed",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.Platform(),
+				},
 			},
 			"version": schema.StringAttribute{
 				MarkdownDescription: "Version of the Hyper Protect Platform",
 				Description:         "Version of the Hyper Protect Platform",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.PlatformVersion(path.Root("platform")),
+				},
 			},
 			"rendered": schema.StringAttribute{
 				MarkdownDescription: "Rendered output of the resource",
@@ -102,35 +151,47 @@ func (r *JSONEncryptedResource) Schema(ctx context.Context, req resource.SchemaR
 				Description:         "SHA256 of the output",
 				Computed:            true,
 			},
+			"compression_ratio": schema.Float64Attribute{
+				MarkdownDescription: "Ratio of the uncompressed to the compressed input size",
+				Description:         "Ratio of the uncompressed to the compressed input size",
+				Computed:            true,
+			},
 		},
 	}
 }
 
-func (r *JSONEncryptedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *JSONEncryptedResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data JSONEncryptedResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get the input JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(data.JSON.ValueString()), &jsonData); err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to decode JSON",
-			fmt.Sprintf("Error decoding JSON: %s", err.Error()),
-		)
+	resp.Diagnostics.Append(validateJSONInput(data.JSON, data.Value)...)
+	resp.Diagnostics.Append(validateCompression(data.Compression, data.Platform, data.Version)...)
+}
+
+func (r *JSONEncryptedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
+func (r *JSONEncryptedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data JSONEncryptedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	jsonBytes, err := json.Marshal(jsonData)
+	// Get the input JSON in its canonical form
+	jsonStr, err := canonicalJSON(data.JSON, data.Value)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to marshal JSON",
-			fmt.Sprintf("Error marshaling JSON: %s", err.Error()),
+			"Failed to serialize JSON",
+			fmt.Sprintf("Error serializing JSON: %s", err.Error()),
 		)
 		return
 	}
+	jsonBytes := []byte(jsonStr)
 
 	// Get the certificate (empty string will use default)
 	cert := ""
@@ -161,8 +222,37 @@ func (r *JSONEncryptedResource) Create(ctx context.Context, req resource.CreateR
 
 	version := data.Version.ValueString()
 
-	// Encrypt JSON using the contract-go library
-	encrypted, inputHash, outputHash, err := contract.HpcrJsonEncrypted(string(jsonBytes), platform, version, cert)
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
+
+	// Make sure the target runtime is able to decompress the payload
+	if err := common.ValidateCompressionSupport(compression, platform, version); err != nil {
+		resp.Diagnostics.AddError(
+			"Unsupported compression",
+			fmt.Sprintf("Error validating compression: %s", err.Error()),
+		)
+		return
+	}
+
+	payload, err := common.Compress(jsonBytes, compression)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to compress JSON",
+			fmt.Sprintf("Error compressing JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	// Encrypt JSON using the contract-go library, a compressed payload is no
+	// longer JSON and is encrypted as text
+	var encrypted, inputHash, outputHash string
+	if compression == common.CompressionNone {
+		encrypted, inputHash, outputHash, err = contract.HpcrJsonEncrypted(string(payload), platform, version, cert)
+	} else {
+		encrypted, inputHash, outputHash, err = contract.HpcrTextEncrypted(string(payload), platform, version, cert)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encrypt JSON",
@@ -171,8 +261,13 @@ func (r *JSONEncryptedResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// sha256_in always refers to the uncompressed JSON document
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(string(jsonBytes))
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash, platform, version, common.GenerateSha256(cert))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -186,6 +281,7 @@ func (r *JSONEncryptedResource) Create(ctx context.Context, req resource.CreateR
 	data.Rendered = types.StringValue(encrypted)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(jsonBytes), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -206,24 +302,16 @@ func (r *JSONEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	// Get the input JSON
-	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(data.JSON.ValueString()), &jsonData); err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to decode JSON",
-			fmt.Sprintf("Error decoding JSON: %s", err.Error()),
-		)
-		return
-	}
-
-	jsonBytes, err := json.Marshal(jsonData)
+	// Get the input JSON in its canonical form
+	jsonStr, err := canonicalJSON(data.JSON, data.Value)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to marshal JSON",
-			fmt.Sprintf("Error marshaling JSON: %s", err.Error()),
+			"Failed to serialize JSON",
+			fmt.Sprintf("Error serializing JSON: %s", err.Error()),
 		)
 		return
 	}
+	jsonBytes := []byte(jsonStr)
 
 	// Get the certificate (empty string will use default)
 	cert := ""
@@ -254,8 +342,53 @@ func (r *JSONEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 
 	version := data.Version.ValueString()
 
-	// Encrypt JSON using the contract-go library
-	encrypted, inputHash, outputHash, err := contract.HpcrJsonEncrypted(string(jsonBytes), platform, version, cert)
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
+
+	// Make sure the target runtime is able to decompress the payload
+	if err := common.ValidateCompressionSupport(compression, platform, version); err != nil {
+		resp.Diagnostics.AddError(
+			"Unsupported compression",
+			fmt.Sprintf("Error validating compression: %s", err.Error()),
+		)
+		return
+	}
+
+	payload, err := common.Compress(jsonBytes, compression)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to compress JSON",
+			fmt.Sprintf("Error compressing JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(string(jsonBytes)))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(string(jsonBytes)))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(jsonBytes), len(payload)))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Encrypt JSON using the contract-go library, a compressed payload is no
+	// longer JSON and is encrypted as text
+	var encrypted, inputHash, outputHash string
+	if compression == common.CompressionNone {
+		encrypted, inputHash, outputHash, err = contract.HpcrJsonEncrypted(string(payload), platform, version, cert)
+	} else {
+		encrypted, inputHash, outputHash, err = contract.HpcrTextEncrypted(string(payload), platform, version, cert)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encrypt JSON",
@@ -264,10 +397,16 @@ func (r *JSONEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	// sha256_in always refers to the uncompressed JSON document
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(string(jsonBytes))
+	}
+
 	// Set the computed fields (keep the existing ID)
 	data.Rendered = types.StringValue(encrypted)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(jsonBytes), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -275,3 +414,13 @@ func (r *JSONEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 func (r *JSONEncryptedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op
 }
+
+func (r *JSONEncryptedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "json_encrypted", r.idMode, req, resp)
+}
+
+func (r *JSONEncryptedResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: addedAttributesUpgrader(jsonEncryptedResourceAttrTypesV0),
+	}
+}
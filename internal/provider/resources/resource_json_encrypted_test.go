@@ -19,6 +19,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
 )
 
 func TestJSONEncryptedResource_Metadata(t *testing.T) {
@@ -49,24 +53,33 @@ func TestJSONEncryptedResource_Schema(t *testing.T) {
 		t.Fatal("Schema attributes should not be nil")
 	}
 
-	requiredAttrs := []string{"id", "json", "cert", "platform", "rendered", "sha256_in", "sha256_out"}
+	requiredAttrs := []string{"id", "json", "value", "cert", "platform", "compression", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("Expected schema to have attribute '%s'", attr)
 		}
 	}
 
-	// Verify json is required
+	// Verify json and value are optional alternatives
 	jsonAttr := resp.Schema.Attributes["json"]
-	if jsonAttr.IsRequired() == false {
-		t.Error("Expected 'json' attribute to be required")
+	if jsonAttr.IsOptional() == false {
+		t.Error("Expected 'json' attribute to be optional")
+	}
+
+	valueAttr := resp.Schema.Attributes["value"]
+	if valueAttr.IsOptional() == false {
+		t.Error("Expected 'value' attribute to be optional")
 	}
 
-	// Verify json is sensitive
+	// Verify json and value are sensitive
 	if jsonAttr.IsSensitive() == false {
 		t.Error("Expected 'json' attribute to be marked as sensitive")
 	}
 
+	if valueAttr.IsSensitive() == false {
+		t.Error("Expected 'value' attribute to be marked as sensitive")
+	}
+
 	// Verify cert is optional
 	certAttr := resp.Schema.Attributes["cert"]
 	if certAttr.IsOptional() == false {
@@ -79,8 +92,14 @@ func TestJSONEncryptedResource_Schema(t *testing.T) {
 		t.Error("Expected 'platform' attribute to be optional")
 	}
 
+	// Verify compression is optional
+	compressionAttr := resp.Schema.Attributes["compression"]
+	if compressionAttr.IsOptional() == false {
+		t.Error("Expected 'compression' attribute to be optional")
+	}
+
 	// Verify computed attributes
-	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out"}
+	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out", "compression_ratio"}
 	for _, attr := range computedAttrs {
 		if resp.Schema.Attributes[attr].IsComputed() == false {
 			t.Errorf("Expected '%s' attribute to be computed", attr)
@@ -138,3 +157,35 @@ func TestJSONEncryptedResource_Delete(t *testing.T) {
 		t.Error("Delete should not produce errors")
 	}
 }
+
+func TestJSONEncryptedResource_PlatformValidators(t *testing.T) {
+	r := NewJSONEncryptedResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify platform and version are validated at plan time
+	for _, attr := range []string{"platform", "version"} {
+		stringAttr, ok := resp.Schema.Attributes[attr].(schema.StringAttribute)
+		if !ok || len(stringAttr.Validators) == 0 {
+			t.Errorf("Expected '%s' attribute to have validators", attr)
+		}
+	}
+}
+
+func TestJSONEncryptedResource_CreateGzipUnsupported(t *testing.T) {
+	r := NewJSONEncryptedResource()
+	plan, state := testPlan(t, r, JSONEncryptedResourceModel{
+		JSON:        types.StringValue(`{"services":{"app":{"image":"example"}}}`),
+		Value:       types.DynamicNull(),
+		Compression: types.StringValue(common.CompressionGzip),
+	})
+
+	resp := &resource.CreateResponse{State: state}
+	r.Create(context.TODO(), resource.CreateRequest{Plan: plan}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unsupported compression" {
+		t.Fatalf("Expected gzip to be rejected without a runtime that decompresses it, got %v", resp.Diagnostics)
+	}
+}
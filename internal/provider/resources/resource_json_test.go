@@ -15,10 +15,17 @@
 package resources
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"io"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
 )
 
 func TestJSONResource_Metadata(t *testing.T) {
@@ -49,26 +56,41 @@ func TestJSONResource_Schema(t *testing.T) {
 		t.Fatal("Schema attributes should not be nil")
 	}
 
-	requiredAttrs := []string{"id", "json", "rendered", "sha256_in", "sha256_out"}
+	requiredAttrs := []string{"id", "json", "value", "compression", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("Expected schema to have attribute '%s'", attr)
 		}
 	}
 
-	// Verify json is required
+	// Verify json and value are optional alternatives
 	jsonAttr := resp.Schema.Attributes["json"]
-	if jsonAttr.IsRequired() == false {
-		t.Error("Expected 'json' attribute to be required")
+	if jsonAttr.IsOptional() == false {
+		t.Error("Expected 'json' attribute to be optional")
+	}
+
+	valueAttr := resp.Schema.Attributes["value"]
+	if valueAttr.IsOptional() == false {
+		t.Error("Expected 'value' attribute to be optional")
 	}
 
-	// Verify json is sensitive
+	// Verify json and value are sensitive
 	if jsonAttr.IsSensitive() == false {
 		t.Error("Expected 'json' attribute to be marked as sensitive")
 	}
 
+	if valueAttr.IsSensitive() == false {
+		t.Error("Expected 'value' attribute to be marked as sensitive")
+	}
+
+	// Verify compression is optional
+	compressionAttr := resp.Schema.Attributes["compression"]
+	if compressionAttr.IsOptional() == false {
+		t.Error("Expected 'compression' attribute to be optional")
+	}
+
 	// Verify computed attributes
-	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out"}
+	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out", "compression_ratio"}
 	for _, attr := range computedAttrs {
 		if resp.Schema.Attributes[attr].IsComputed() == false {
 			t.Errorf("Expected '%s' attribute to be computed", attr)
@@ -126,3 +148,38 @@ func TestJSONResource_Delete(t *testing.T) {
 		t.Error("Delete should not produce errors")
 	}
 }
+
+func TestJSONResource_CreateGzip(t *testing.T) {
+	jsonStr := `{"services":{"app":{"environment":{"LOG_LEVEL":"info"},"image":"example"}}}`
+
+	var data JSONResourceModel
+	testCreate(t, NewJSONResource(), JSONResourceModel{
+		JSON:        types.StringValue(jsonStr),
+		Value:       types.DynamicNull(),
+		Compression: types.StringValue(common.CompressionGzip),
+	}, &data)
+
+	// The rendered output is the base64 encoded gzip payload of the JSON document
+	compressed, err := base64.StdEncoding.DecodeString(data.Rendered.ValueString())
+	if err != nil {
+		t.Fatalf("Expected base64 output, got error: %v", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("Expected gzip payload, got error: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to decompress output: %v", err)
+	}
+	if string(decompressed) != jsonStr {
+		t.Errorf("Expected %q, got %q", jsonStr, decompressed)
+	}
+
+	if data.Sha256In.ValueString() != common.GenerateSha256(jsonStr) {
+		t.Error("Expected sha256_in to refer to the uncompressed JSON document")
+	}
+	if data.CompressionRatio.IsNull() {
+		t.Error("Expected compression_ratio to be set")
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ resource.Resource = &PeerPodInitDataResource{}
+var _ resource.ResourceWithConfigure = &PeerPodInitDataResource{}
+var _ resource.ResourceWithImportState = &PeerPodInitDataResource{}
+
+func NewPeerPodInitDataResource() resource.Resource {
+	return &PeerPodInitDataResource{}
+}
+
+type PeerPodInitDataResource struct {
+	idMode string
+}
+
+type PeerPodInitDataResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Contract      types.String `tfsdk:"contract"`
+	ContractKey   types.String `tfsdk:"contract_key"`
+	Data          types.Map    `tfsdk:"data"`
+	Algorithm     types.String `tfsdk:"algorithm"`
+	InitDataTOML  types.String `tfsdk:"initdata_toml"`
+	K8sAnnotation types.String `tfsdk:"k8s_annotation"`
+	AnnotationKey types.String `tfsdk:"annotation_key"`
+	Digest        types.String `tfsdk:"digest"`
+}
+
+func (r *PeerPodInitDataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_peerpod_initdata"
+}
+
+func (r *PeerPodInitDataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Wraps an encrypted contract for the `hpcc-peerpod` platform into the initdata document of a confidential container and the matching pod annotation.",
+		Description:         "Wraps an encrypted contract into the initdata of a confidential container.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"contract": schema.StringAttribute{
+				MarkdownDescription: "Encrypted contract, typically the `rendered` output of `hpcr_contract_encrypted` with `platform = \"hpcc-peerpod\"`",
+				Description:         "Encrypted contract",
+				Required:            true,
+			},
+			"contract_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the contract in the data table of the initdata. Defaults to `user-data`.",
+				Description:         "Key of the contract in the data table of the initdata",
+				Optional:            true,
+			},
+			"data": schema.MapAttribute{
+				MarkdownDescription: "Additional entries of the data table of the initdata, e.g. `aa.toml`, `cdh.toml` or `policy.rego`",
+				Description:         "Additional entries of the data table of the initdata",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Hash algorithm of the initdata, one of `sha256`, `sha384` or `sha512`. Defaults to `sha256`.",
+				Description:         "Hash algorithm of the initdata, one of sha256, sha384 or sha512",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.DigestSHA256, common.DigestSHA384, common.DigestSHA512),
+				},
+			},
+			"initdata_toml": schema.StringAttribute{
+				MarkdownDescription: "Initdata document in TOML format, e.g. for an initdata ConfigMap",
+				Description:         "Initdata document in TOML format",
+				Computed:            true,
+			},
+			"k8s_annotation": schema.StringAttribute{
+				MarkdownDescription: "Gzip compressed and base64 encoded initdata, the value of the `annotation_key` pod annotation",
+				Description:         "Gzip compressed and base64 encoded initdata",
+				Computed:            true,
+			},
+			"annotation_key": schema.StringAttribute{
+				MarkdownDescription: "Name of the pod annotation that carries the initdata, `io.katacontainers.config.runtime.cc_init_data`",
+				Description:         "Name of the pod annotation that carries the initdata",
+				Computed:            true,
+			},
+			"digest": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded digest of the initdata document using `algorithm`, as reported in the attestation evidence",
+				Description:         "Hex encoded digest of the initdata document",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *PeerPodInitDataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
+func (r *PeerPodInitDataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PeerPodInitDataResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := make(map[string]string)
+	if !data.Data.IsNull() && !data.Data.IsUnknown() {
+		resp.Diagnostics.Append(data.Data.ElementsAs(ctx, &entries, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	contractKey := common.DefaultInitDataContractKey
+	if !data.ContractKey.IsNull() && !data.ContractKey.IsUnknown() {
+		contractKey = data.ContractKey.ValueString()
+	}
+	if _, ok := entries[contractKey]; ok {
+		resp.Diagnostics.AddError(
+			"Duplicate initdata entry",
+			fmt.Sprintf("The data map must not contain the contract key '%s'", contractKey),
+		)
+		return
+	}
+	entries[contractKey] = data.Contract.ValueString()
+
+	algorithm := common.DigestSHA256
+	if !data.Algorithm.IsNull() && !data.Algorithm.IsUnknown() {
+		algorithm = data.Algorithm.ValueString()
+	}
+
+	// Render the initdata and its encodings
+	initData, err := common.InitDataTOML(algorithm, entries)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to render initdata",
+			fmt.Sprintf("Error rendering initdata: %s", err.Error()),
+		)
+		return
+	}
+
+	annotation, err := common.InitDataAnnotationValue(initData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to encode initdata",
+			fmt.Sprintf("Error encoding initdata annotation: %s", err.Error()),
+		)
+		return
+	}
+
+	digest, err := common.InitDataDigest(algorithm, initData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to hash initdata",
+			fmt.Sprintf("Error computing initdata digest: %s", err.Error()),
+		)
+		return
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, algorithm, digest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields
+	data.ID = types.StringValue(id)
+	data.InitDataTOML = types.StringValue(initData)
+	data.K8sAnnotation = types.StringValue(annotation)
+	data.AnnotationKey = types.StringValue(common.InitDataAnnotation)
+	data.Digest = types.StringValue(digest)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PeerPodInitDataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PeerPodInitDataResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PeerPodInitDataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PeerPodInitDataResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := make(map[string]string)
+	if !data.Data.IsNull() && !data.Data.IsUnknown() {
+		resp.Diagnostics.Append(data.Data.ElementsAs(ctx, &entries, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	contractKey := common.DefaultInitDataContractKey
+	if !data.ContractKey.IsNull() && !data.ContractKey.IsUnknown() {
+		contractKey = data.ContractKey.ValueString()
+	}
+	if _, ok := entries[contractKey]; ok {
+		resp.Diagnostics.AddError(
+			"Duplicate initdata entry",
+			fmt.Sprintf("The data map must not contain the contract key '%s'", contractKey),
+		)
+		return
+	}
+	entries[contractKey] = data.Contract.ValueString()
+
+	algorithm := common.DigestSHA256
+	if !data.Algorithm.IsNull() && !data.Algorithm.IsUnknown() {
+		algorithm = data.Algorithm.ValueString()
+	}
+
+	// Render the initdata and its encodings
+	initData, err := common.InitDataTOML(algorithm, entries)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to render initdata",
+			fmt.Sprintf("Error rendering initdata: %s", err.Error()),
+		)
+		return
+	}
+
+	annotation, err := common.InitDataAnnotationValue(initData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to encode initdata",
+			fmt.Sprintf("Error encoding initdata annotation: %s", err.Error()),
+		)
+		return
+	}
+
+	digest, err := common.InitDataDigest(algorithm, initData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to hash initdata",
+			fmt.Sprintf("Error computing initdata digest: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields (keep the existing ID)
+	data.InitDataTOML = types.StringValue(initData)
+	data.K8sAnnotation = types.StringValue(annotation)
+	data.AnnotationKey = types.StringValue(common.InitDataAnnotation)
+	data.Digest = types.StringValue(digest)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PeerPodInitDataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No-op
+}
+
+func (r *PeerPodInitDataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, ok := splitImportID(req.ID, "peerpod_initdata", 1)
+	if ok {
+		_, err := hex.DecodeString(parts[0])
+		ok = err == nil
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form peerpod_initdata:<digest>, got '%s'", req.ID),
+		)
+		return
+	}
+
+	id, err := common.ResourceID(r.idMode, parts[0])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// The initdata is derived from the inputs only, so the next apply renders the same documents again
+	data := PeerPodInitDataResourceModel{
+		ID:            types.StringValue(id),
+		Contract:      types.StringNull(),
+		ContractKey:   types.StringNull(),
+		Data:          types.MapNull(types.StringType),
+		Algorithm:     types.StringNull(),
+		InitDataTOML:  types.StringNull(),
+		K8sAnnotation: types.StringNull(),
+		AnnotationKey: types.StringValue(common.InitDataAnnotation),
+		Digest:        types.StringValue(parts[0]),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func TestPeerPodInitDataResource_Metadata(t *testing.T) {
+	r := NewPeerPodInitDataResource()
+
+	req := resource.MetadataRequest{
+		ProviderTypeName: "hpcr",
+	}
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.TODO(), req, resp)
+
+	if resp.TypeName != "hpcr_peerpod_initdata" {
+		t.Errorf("Expected TypeName to be 'hpcr_peerpod_initdata', got '%s'", resp.TypeName)
+	}
+}
+
+func TestPeerPodInitDataResource_Schema(t *testing.T) {
+	r := NewPeerPodInitDataResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify schema has required attributes
+	if resp.Schema.Attributes == nil {
+		t.Fatal("Schema attributes should not be nil")
+	}
+
+	requiredAttrs := []string{"id", "contract", "contract_key", "data", "algorithm", "initdata_toml", "k8s_annotation", "annotation_key", "digest"}
+	for _, attr := range requiredAttrs {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected schema to have attribute '%s'", attr)
+		}
+	}
+
+	// Verify contract is required
+	if resp.Schema.Attributes["contract"].IsRequired() == false {
+		t.Error("Expected 'contract' attribute to be required")
+	}
+
+	// Verify optional attributes
+	for _, attr := range []string{"contract_key", "data", "algorithm"} {
+		if resp.Schema.Attributes[attr].IsOptional() == false {
+			t.Errorf("Expected '%s' attribute to be optional", attr)
+		}
+	}
+
+	// Verify computed attributes
+	for _, attr := range []string{"id", "initdata_toml", "k8s_annotation", "annotation_key", "digest"} {
+		if resp.Schema.Attributes[attr].IsComputed() == false {
+			t.Errorf("Expected '%s' attribute to be computed", attr)
+		}
+	}
+
+	// Verify algorithm is validated by the schema
+	if len(resp.Schema.Attributes["algorithm"].(schema.StringAttribute).Validators) == 0 {
+		t.Error("Expected 'algorithm' attribute to have a validator")
+	}
+}
+
+func TestNewPeerPodInitDataResource(t *testing.T) {
+	r := NewPeerPodInitDataResource()
+	if r == nil {
+		t.Fatal("NewPeerPodInitDataResource should not return nil")
+	}
+
+	// Verify it implements the Resource interface
+	var _ resource.Resource = &PeerPodInitDataResource{}
+}
+
+func TestPeerPodInitDataResource_SchemaDescriptions(t *testing.T) {
+	r := NewPeerPodInitDataResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify schema has descriptions
+	if resp.Schema.Description == "" {
+		t.Error("Expected schema to have a description")
+	}
+
+	if resp.Schema.MarkdownDescription == "" {
+		t.Error("Expected schema to have a markdown description")
+	}
+
+	// Verify attributes have descriptions (either Description or MarkdownDescription)
+	for name, attr := range resp.Schema.Attributes {
+		desc := attr.GetDescription()
+		mdDesc := attr.GetMarkdownDescription()
+		if desc == "" && mdDesc == "" {
+			t.Errorf("Expected attribute '%s' to have a description or markdown description", name)
+		}
+	}
+}
+
+func TestPeerPodInitDataResource_Delete(t *testing.T) {
+	r := &PeerPodInitDataResource{}
+
+	req := resource.DeleteRequest{}
+	resp := &resource.DeleteResponse{}
+
+	// Delete should be a no-op and not produce any errors
+	r.Delete(context.TODO(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Error("Delete should not produce errors")
+	}
+}
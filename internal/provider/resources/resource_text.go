@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
@@ -29,19 +32,38 @@ import (
 )
 
 var _ resource.Resource = &TextResource{}
+var _ resource.ResourceWithConfigure = &TextResource{}
+var _ resource.ResourceWithImportState = &TextResource{}
+var _ resource.ResourceWithUpgradeState = &TextResource{}
+var _ resource.ResourceWithValidateConfig = &TextResource{}
 
 func NewTextResource() resource.Resource {
 	return &TextResource{}
 }
 
-type TextResource struct{}
+type TextResource struct {
+	idMode string
+}
 
 type TextResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Text      types.String `tfsdk:"text"`
-	Rendered  types.String `tfsdk:"rendered"`
-	Sha256In  types.String `tfsdk:"sha256_in"`
-	Sha256Out types.String `tfsdk:"sha256_out"`
+	ID               types.String  `tfsdk:"id"`
+	Text             types.String  `tfsdk:"text"`
+	ContentBase64    types.String  `tfsdk:"content_base64"`
+	Compression      types.String  `tfsdk:"compression"`
+	Rendered         types.String  `tfsdk:"rendered"`
+	Sha256In         types.String  `tfsdk:"sha256_in"`
+	Sha256Out        types.String  `tfsdk:"sha256_out"`
+	CompressionRatio types.Float64 `tfsdk:"compression_ratio"`
+}
+
+// textResourceAttrTypesV0 are the attribute types of schema version 0,
+// before content_base64, compression and compression_ratio were added.
+var textResourceAttrTypesV0 = map[string]attr.Type{
+	"id":         types.StringType,
+	"text":       types.StringType,
+	"rendered":   types.StringType,
+	"sha256_in":  types.StringType,
+	"sha256_out": types.StringType,
 }
 
 func (r *TextResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,6 +72,8 @@ func (r *TextResource) Metadata(ctx context.Context, req resource.MetadataReques
 
 func (r *TextResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
+
 		MarkdownDescription: "Generates a base64 encoded token from text input.",
 		Description:         "Generates a base64 encoded token from text input.",
 
@@ -62,11 +86,25 @@ func (r *TextResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"text": schema.StringAttribute{
-				MarkdownDescription: "Text to archive",
+				MarkdownDescription: "Text to archive. Exactly one of `text` or `content_base64` must be provided.",
 				Description:         "Text to archive",
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64-encoded binary content to archive (use `filebase64()` in Terraform). The content is decoded before it is encoded. Exactly one of `text` or `content_base64` must be provided.",
+				Description:         "Base64-encoded binary content to archive",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"compression": schema.StringAttribute{
+				MarkdownDescription: "Compression applied to the text before it is encoded, one of `none` or `gzip`. Defaults to `none`.",
+				Description:         "Compression applied to the text before it is encoded, one of none or gzip",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.CompressionNone, common.CompressionGzip),
+				},
+			},
 			"rendered": schema.StringAttribute{
 				MarkdownDescription: "Rendered output of the resource",
 				Description:         "Rendered output of the resource",
@@ -82,10 +120,29 @@ func (r *TextResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description:         "SHA256 of the output",
 				Computed:            true,
 			},
+			"compression_ratio": schema.Float64Attribute{
+				MarkdownDescription: "Ratio of the uncompressed to the compressed input size",
+				Description:         "Ratio of the uncompressed to the compressed input size",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+func (r *TextResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TextResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateTextInput(data.Text, data.ContentBase64)...)
+}
+
+func (r *TextResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
 func (r *TextResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data TextResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -93,11 +150,33 @@ func (r *TextResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Get the input text
-	plainText := data.Text.ValueString()
+	// Get the input text or the decoded binary content
+	input, err := textInput(data.Text, data.ContentBase64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid text input",
+			fmt.Sprintf("Error reading input: %s", err.Error()),
+		)
+		return
+	}
+	plainText := string(input)
+
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
+
+	payload, err := common.Compress([]byte(plainText), compression)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to compress text",
+			fmt.Sprintf("Error compressing text: %s", err.Error()),
+		)
+		return
+	}
 
 	// Encode text using the contract-go library
-	encoded, inputHash, outputHash, err := contract.HpcrText(plainText)
+	encoded, inputHash, outputHash, err := contract.HpcrText(string(payload))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encode text",
@@ -106,8 +185,13 @@ func (r *TextResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// sha256_in always refers to the uncompressed text
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(plainText)
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -121,6 +205,7 @@ func (r *TextResource) Create(ctx context.Context, req resource.CreateRequest, r
 	data.Rendered = types.StringValue(encoded)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(plainText), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -141,11 +226,49 @@ func (r *TextResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// Get the input text
-	plainText := data.Text.ValueString()
+	// Get the input text or the decoded binary content
+	input, err := textInput(data.Text, data.ContentBase64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid text input",
+			fmt.Sprintf("Error reading input: %s", err.Error()),
+		)
+		return
+	}
+	plainText := string(input)
+
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
+
+	payload, err := common.Compress([]byte(plainText), compression)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to compress text",
+			fmt.Sprintf("Error compressing text: %s", err.Error()),
+		)
+		return
+	}
+
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(plainText))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(plainText))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(plainText), len(payload)))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
 	// Encode text using the contract-go library
-	encoded, inputHash, outputHash, err := contract.HpcrText(plainText)
+	encoded, inputHash, outputHash, err := contract.HpcrText(string(payload))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encode text",
@@ -154,10 +277,16 @@ func (r *TextResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// sha256_in always refers to the uncompressed text
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(plainText)
+	}
+
 	// Set the computed fields (keep the existing ID)
 	data.Rendered = types.StringValue(encoded)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(plainText), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -165,3 +294,13 @@ func (r *TextResource) Update(ctx context.Context, req resource.UpdateRequest, r
 func (r *TextResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op
 }
+
+func (r *TextResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "text", r.idMode, req, resp)
+}
+
+func (r *TextResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: addedAttributesUpgrader(textResourceAttrTypesV0),
+	}
+}
@@ -18,34 +18,61 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
 )
 
 var _ resource.Resource = &TextEncryptedResource{}
+var _ resource.ResourceWithConfigure = &TextEncryptedResource{}
+var _ resource.ResourceWithImportState = &TextEncryptedResource{}
+var _ resource.ResourceWithUpgradeState = &TextEncryptedResource{}
+var _ resource.ResourceWithValidateConfig = &TextEncryptedResource{}
 
 func NewTextEncryptedResource() resource.Resource {
 	return &TextEncryptedResource{}
 }
 
-type TextEncryptedResource struct{}
+type TextEncryptedResource struct {
+	idMode string
+}
 
 type TextEncryptedResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Text      types.String `tfsdk:"text"`
-	Cert      types.String `tfsdk:"cert"`
-	Platform  types.String `tfsdk:"platform"`
-	Version   types.String `tfsdk:"version"`
-	Rendered  types.String `tfsdk:"rendered"`
-	Sha256In  types.String `tfsdk:"sha256_in"`
-	Sha256Out types.String `tfsdk:"sha256_out"`
+	ID               types.String  `tfsdk:"id"`
+	Text             types.String  `tfsdk:"text"`
+	ContentBase64    types.String  `tfsdk:"content_base64"`
+	Compression      types.String  `tfsdk:"compression"`
+	Cert             types.String  `tfsdk:"cert"`
+	Platform         types.String  `tfsdk:"platform"`
+	Version          types.String  `tfsdk:"version"`
+	Rendered         types.String  `tfsdk:"rendered"`
+	Sha256In         types.String  `tfsdk:"sha256_in"`
+	Sha256Out        types.String  `tfsdk:"sha256_out"`
+	CompressionRatio types.Float64 `tfsdk:"compression_ratio"`
+}
+
+// textEncryptedResourceAttrTypesV0 are the attribute types of schema version 0,
+// before content_base64, compression and compression_ratio were added.
+var textEncryptedResourceAttrTypesV0 = map[string]attr.Type{
+	"id":         types.StringType,
+	"text":       types.StringType,
+	"cert":       types.StringType,
+	"platform":   types.StringType,
+	"version":    types.StringType,
+	"rendered":   types.StringType,
+	"sha256_in":  types.StringType,
+	"sha256_out": types.StringType,
 }
 
 func (r *TextEncryptedResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,6 +81,8 @@ func (r *TextEncryptedResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *TextEncryptedResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
+
 		MarkdownDescription: "Generates an encrypted token from text input.",
 		Description:         "Generates an encrypted token from text input.",
 
@@ -66,11 +95,26 @@ func (r *TextEncryptedResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"text": schema.StringAttribute{
-				MarkdownDescription: "Text to archive",
+				MarkdownDescription: "Text to archive. Exactly one of `text` or `content_base64` must be provided.",
 				Description:         "Text to archive",
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64-encoded binary content to archive (use `filebase64()` in Terraform). The content is decoded before it is encrypted. Exactly one of `text` or `content_base64` must be provided.",
+				Description:         "Base64-encoded binary content to archive",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"compression": schema.StringAttribute{
+				MarkdownDescription: "Compression applied to the text before it is encrypted, one of `none` or `gzip`. Defaults to `none`. " +
+					"Compressed payloads are rejected for platforms and versions whose runtime is not documented to decompress them.",
+				Description: "Compression applied to the text before it is encrypted, one of none or gzip",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(common.CompressionNone, common.CompressionGzip),
+				},
+			},
 			"cert": schema.StringAttribute{
 				MarkdownDescription: "Certificate used to encrypt the text, in PEM format. Defaults to the latest HPCR image certificate if not specified.",
 				Description:         "Certificate used to encrypt the text, in PEM format",
@@ -80,11 +124,17 @@ func (r *TextEncryptedResource) Schema(ctx context.Context, req resource.SchemaR
 				MarkdownDescription: "Hyper Protect platform where this contract will be deployed. Defaults to hpvs",
 				Description:         "Hyper Protect platform where this contract will be deployed",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.Platform(),
+				},
 			},
 			"version": schema.StringAttribute{
 				MarkdownDescription: "Version of the Hyper Protect Platform",
 				Description:         "Version of the Hyper Protect Platform",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.PlatformVersion(path.Root("platform")),
+				},
 			},
 			"rendered": schema.StringAttribute{
 				MarkdownDescription: "Rendered output of the resource",
@@ -101,10 +151,30 @@ func (r *TextEncryptedResource) Schema(ctx context.Context, req resource.SchemaR
 				Description:         "SHA256 of the output",
 				Computed:            true,
 			},
+			"compression_ratio": schema.Float64Attribute{
+				MarkdownDescription: "Ratio of the uncompressed to the compressed input size",
+				Description:         "Ratio of the uncompressed to the compressed input size",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+func (r *TextEncryptedResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TextEncryptedResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateTextInput(data.Text, data.ContentBase64)...)
+	resp.Diagnostics.Append(validateCompression(data.Compression, data.Platform, data.Version)...)
+}
+
+func (r *TextEncryptedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
 func (r *TextEncryptedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data TextEncryptedResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -112,8 +182,16 @@ func (r *TextEncryptedResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// Get the input text
-	plainText := data.Text.ValueString()
+	// Get the input text or the decoded binary content
+	input, err := textInput(data.Text, data.ContentBase64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid text input",
+			fmt.Sprintf("Error reading input: %s", err.Error()),
+		)
+		return
+	}
+	plainText := string(input)
 
 	// Get the certificate (empty string will use default)
 	cert := ""
@@ -143,9 +221,32 @@ func (r *TextEncryptedResource) Create(ctx context.Context, req resource.CreateR
 
 	version := data.Version.ValueString()
 
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
+
+	// Make sure the target runtime is able to decompress the payload
+	if err := common.ValidateCompressionSupport(compression, platform, version); err != nil {
+		resp.Diagnostics.AddError(
+			"Unsupported compression",
+			fmt.Sprintf("Error validating compression: %s", err.Error()),
+		)
+		return
+	}
+
+	payload, err := common.Compress([]byte(plainText), compression)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to compress text",
+			fmt.Sprintf("Error compressing text: %s", err.Error()),
+		)
+		return
+	}
+
 	// Encrypt text using the contract-go library
 	// Use empty string for hyperProtectOs to use default ("hpvs")
-	encrypted, inputHash, outputHash, err := contract.HpcrTextEncrypted(plainText, platform, version, cert)
+	encrypted, inputHash, outputHash, err := contract.HpcrTextEncrypted(string(payload), platform, version, cert)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encrypt text",
@@ -154,8 +255,13 @@ func (r *TextEncryptedResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// sha256_in always refers to the uncompressed text
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(plainText)
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash, platform, version, common.GenerateSha256(cert))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -169,6 +275,7 @@ func (r *TextEncryptedResource) Create(ctx context.Context, req resource.CreateR
 	data.Rendered = types.StringValue(encrypted)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(plainText), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -189,8 +296,16 @@ func (r *TextEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	// Get the input text
-	plainText := data.Text.ValueString()
+	// Get the input text or the decoded binary content
+	input, err := textInput(data.Text, data.ContentBase64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid text input",
+			fmt.Sprintf("Error reading input: %s", err.Error()),
+		)
+		return
+	}
+	plainText := string(input)
 
 	// Get the certificate (empty string will use default)
 	cert := ""
@@ -220,9 +335,48 @@ func (r *TextEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 
 	version := data.Version.ValueString()
 
+	compression := common.CompressionNone
+	if !data.Compression.IsNull() && !data.Compression.IsUnknown() {
+		compression = data.Compression.ValueString()
+	}
+
+	// Make sure the target runtime is able to decompress the payload
+	if err := common.ValidateCompressionSupport(compression, platform, version); err != nil {
+		resp.Diagnostics.AddError(
+			"Unsupported compression",
+			fmt.Sprintf("Error validating compression: %s", err.Error()),
+		)
+		return
+	}
+
+	payload, err := common.Compress([]byte(plainText), compression)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to compress text",
+			fmt.Sprintf("Error compressing text: %s", err.Error()),
+		)
+		return
+	}
+
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(plainText))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(plainText))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(plainText), len(payload)))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// Encrypt text using the contract-go library
 	// Use empty string for hyperProtectOs to use default ("hpvs")
-	encrypted, inputHash, outputHash, err := contract.HpcrTextEncrypted(plainText, platform, version, cert)
+	encrypted, inputHash, outputHash, err := contract.HpcrTextEncrypted(string(payload), platform, version, cert)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encrypt text",
@@ -231,10 +385,16 @@ func (r *TextEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	// sha256_in always refers to the uncompressed text
+	if compression != common.CompressionNone {
+		inputHash = common.GenerateSha256(plainText)
+	}
+
 	// Set the computed fields (keep the existing ID)
 	data.Rendered = types.StringValue(encrypted)
 	data.Sha256In = types.StringValue(inputHash)
 	data.Sha256Out = types.StringValue(outputHash)
+	data.CompressionRatio = types.Float64Value(common.CompressionRatio(len(plainText), len(payload)))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -242,3 +402,13 @@ func (r *TextEncryptedResource) Update(ctx context.Context, req resource.UpdateR
 func (r *TextEncryptedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op
 }
+
+func (r *TextEncryptedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "text_encrypted", r.idMode, req, resp)
+}
+
+func (r *TextEncryptedResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: addedAttributesUpgrader(textEncryptedResourceAttrTypesV0),
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 func TestTextEncryptedResource_Metadata(t *testing.T) {
@@ -49,17 +50,17 @@ func TestTextEncryptedResource_Schema(t *testing.T) {
 		t.Fatal("Schema attributes should not be nil")
 	}
 
-	requiredAttrs := []string{"id", "text", "cert", "platform", "rendered", "sha256_in", "sha256_out"}
+	requiredAttrs := []string{"id", "text", "content_base64", "cert", "platform", "compression", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("Expected schema to have attribute '%s'", attr)
 		}
 	}
 
-	// Verify text is required
+	// Verify text is optional
 	textAttr := resp.Schema.Attributes["text"]
-	if textAttr.IsRequired() == false {
-		t.Error("Expected 'text' attribute to be required")
+	if textAttr.IsOptional() == false {
+		t.Error("Expected 'text' attribute to be optional")
 	}
 
 	// Verify text is sensitive
@@ -79,8 +80,23 @@ func TestTextEncryptedResource_Schema(t *testing.T) {
 		t.Error("Expected 'platform' attribute to be optional")
 	}
 
+	// Verify content_base64 is optional and sensitive
+	contentAttr := resp.Schema.Attributes["content_base64"]
+	if contentAttr.IsOptional() == false {
+		t.Error("Expected 'content_base64' attribute to be optional")
+	}
+	if contentAttr.IsSensitive() == false {
+		t.Error("Expected 'content_base64' attribute to be marked as sensitive")
+	}
+
+	// Verify compression is optional
+	compressionAttr := resp.Schema.Attributes["compression"]
+	if compressionAttr.IsOptional() == false {
+		t.Error("Expected 'compression' attribute to be optional")
+	}
+
 	// Verify computed attributes
-	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out"}
+	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out", "compression_ratio"}
 	for _, attr := range computedAttrs {
 		if resp.Schema.Attributes[attr].IsComputed() == false {
 			t.Errorf("Expected '%s' attribute to be computed", attr)
@@ -138,3 +154,20 @@ func TestTextEncryptedResource_Delete(t *testing.T) {
 		t.Error("Delete should not produce errors")
 	}
 }
+
+func TestTextEncryptedResource_PlatformValidators(t *testing.T) {
+	r := NewTextEncryptedResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify platform and version are validated at plan time
+	for _, attr := range []string{"platform", "version"} {
+		stringAttr, ok := resp.Schema.Attributes[attr].(schema.StringAttribute)
+		if !ok || len(stringAttr.Validators) == 0 {
+			t.Errorf("Expected '%s' attribute to have validators", attr)
+		}
+	}
+}
@@ -18,7 +18,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestTextResource_Metadata(t *testing.T) {
@@ -49,17 +53,17 @@ func TestTextResource_Schema(t *testing.T) {
 		t.Fatal("Schema attributes should not be nil")
 	}
 
-	requiredAttrs := []string{"id", "text", "rendered", "sha256_in", "sha256_out"}
+	requiredAttrs := []string{"id", "text", "content_base64", "compression", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("Expected schema to have attribute '%s'", attr)
 		}
 	}
 
-	// Verify text is required
+	// Verify text is optional
 	textAttr := resp.Schema.Attributes["text"]
-	if textAttr.IsRequired() == false {
-		t.Error("Expected 'text' attribute to be required")
+	if textAttr.IsOptional() == false {
+		t.Error("Expected 'text' attribute to be optional")
 	}
 
 	// Verify text is sensitive
@@ -67,8 +71,23 @@ func TestTextResource_Schema(t *testing.T) {
 		t.Error("Expected 'text' attribute to be marked as sensitive")
 	}
 
+	// Verify content_base64 is optional and sensitive
+	contentAttr := resp.Schema.Attributes["content_base64"]
+	if contentAttr.IsOptional() == false {
+		t.Error("Expected 'content_base64' attribute to be optional")
+	}
+	if contentAttr.IsSensitive() == false {
+		t.Error("Expected 'content_base64' attribute to be marked as sensitive")
+	}
+
+	// Verify compression is optional
+	compressionAttr := resp.Schema.Attributes["compression"]
+	if compressionAttr.IsOptional() == false {
+		t.Error("Expected 'compression' attribute to be optional")
+	}
+
 	// Verify computed attributes
-	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out"}
+	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out", "compression_ratio"}
 	for _, attr := range computedAttrs {
 		if resp.Schema.Attributes[attr].IsComputed() == false {
 			t.Errorf("Expected '%s' attribute to be computed", attr)
@@ -126,3 +145,37 @@ func TestTextResource_Delete(t *testing.T) {
 		t.Error("Delete should not produce errors")
 	}
 }
+
+func TestCompressionValidators(t *testing.T) {
+	for _, r := range []resource.Resource{NewTextResource(), NewTextEncryptedResource(), NewJSONResource(), NewJSONEncryptedResource()} {
+		resp := &resource.SchemaResponse{}
+		r.Schema(context.TODO(), resource.SchemaRequest{}, resp)
+
+		attribute := resp.Schema.Attributes["compression"].(schema.StringAttribute)
+		for value, wantErr := range map[string]bool{"none": false, "gzip": false, "zstd": true, "": true} {
+			validateResp := &validator.StringResponse{}
+			for _, v := range attribute.Validators {
+				v.ValidateString(context.TODO(), validator.StringRequest{Path: path.Root("compression"), ConfigValue: types.StringValue(value)}, validateResp)
+			}
+			if validateResp.Diagnostics.HasError() != wantErr {
+				t.Errorf("Expected compression %q to be rejected: %v, got %v", value, wantErr, validateResp.Diagnostics)
+			}
+		}
+	}
+
+	for _, r := range []resource.Resource{NewTgzResource(), NewTgzEncryptedResource()} {
+		resp := &resource.SchemaResponse{}
+		r.Schema(context.TODO(), resource.SchemaRequest{}, resp)
+
+		attribute := resp.Schema.Attributes["compression_level"].(schema.Int64Attribute)
+		for value, wantErr := range map[int64]bool{0: true, 1: false, 9: false, 10: true} {
+			validateResp := &validator.Int64Response{}
+			for _, v := range attribute.Validators {
+				v.ValidateInt64(context.TODO(), validator.Int64Request{Path: path.Root("compression_level"), ConfigValue: types.Int64Value(value)}, validateResp)
+			}
+			if validateResp.Diagnostics.HasError() != wantErr {
+				t.Errorf("Expected compression_level %d to be rejected: %v, got %v", value, wantErr, validateResp.Diagnostics)
+			}
+		}
+	}
+}
@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
@@ -30,21 +33,37 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TgzResource{}
+var _ resource.ResourceWithConfigure = &TgzResource{}
+var _ resource.ResourceWithImportState = &TgzResource{}
+var _ resource.ResourceWithUpgradeState = &TgzResource{}
 
 func NewTgzResource() resource.Resource {
 	return &TgzResource{}
 }
 
 // TgzResource defines the resource implementation.
-type TgzResource struct{}
+type TgzResource struct {
+	idMode string
+}
 
 // TgzResourceModel describes the resource data model.
 type TgzResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Folder    types.String `tfsdk:"folder"`
-	Rendered  types.String `tfsdk:"rendered"`
-	Sha256In  types.String `tfsdk:"sha256_in"`
-	Sha256Out types.String `tfsdk:"sha256_out"`
+	ID               types.String `tfsdk:"id"`
+	Folder           types.String `tfsdk:"folder"`
+	CompressionLevel types.Int64  `tfsdk:"compression_level"`
+	Rendered         types.String `tfsdk:"rendered"`
+	Sha256In         types.String `tfsdk:"sha256_in"`
+	Sha256Out        types.String `tfsdk:"sha256_out"`
+}
+
+// tgzResourceAttrTypesV0 are the attribute types of schema version 0,
+// before compression_level was added.
+var tgzResourceAttrTypesV0 = map[string]attr.Type{
+	"id":         types.StringType,
+	"folder":     types.StringType,
+	"rendered":   types.StringType,
+	"sha256_in":  types.StringType,
+	"sha256_out": types.StringType,
 }
 
 func (r *TgzResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +72,8 @@ func (r *TgzResource) Metadata(ctx context.Context, req resource.MetadataRequest
 
 func (r *TgzResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
+
 		MarkdownDescription: "Generates a base64 encoded string from the TGZed files in the folder.",
 		Description:         "Generates a base64 encoded string from the TGZed files in the folder.",
 
@@ -70,6 +91,14 @@ func (r *TgzResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Description:         "Path to the folder to archive",
 				Required:            true,
 			},
+			"compression_level": schema.Int64Attribute{
+				MarkdownDescription: "Gzip compression level of the archive, from `1` (fastest) to `9` (smallest). Defaults to the standard gzip level if not specified.",
+				Description:         "Gzip compression level of the archive, from 1 (fastest) to 9 (smallest)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 9),
+				},
+			},
 			"rendered": schema.StringAttribute{
 				MarkdownDescription: "Rendered output of the resource",
 				Description:         "Rendered output of the resource",
@@ -89,6 +118,10 @@ func (r *TgzResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 	}
 }
 
+func (r *TgzResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
 func (r *TgzResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data TgzResourceModel
 
@@ -102,8 +135,17 @@ func (r *TgzResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// Get the folder path
 	folderPath := data.Folder.ValueString()
 
-	// Create TGZ archive using the contract-go library
-	tgzBase64, inputHash, outputHash, err := contract.HpcrTgz(folderPath)
+	var tgzBase64, inputHash, outputHash string
+	var err error
+	if !data.CompressionLevel.IsNull() && !data.CompressionLevel.IsUnknown() {
+		// Build the archive locally to honour the requested compression level
+		tgzBase64, err = common.GenerateTgzBase64(folderPath, int(data.CompressionLevel.ValueInt64()))
+		inputHash = common.GenerateSha256(folderPath)
+		outputHash = common.GenerateSha256(tgzBase64)
+	} else {
+		// Create TGZ archive using the contract-go library
+		tgzBase64, inputHash, outputHash, err = contract.HpcrTgz(folderPath)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create TGZ archive",
@@ -112,8 +154,8 @@ func (r *TgzResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -162,8 +204,32 @@ func (r *TgzResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// Get the folder path
 	folderPath := data.Folder.ValueString()
 
-	// Create TGZ archive using the contract-go library
-	tgzBase64, inputHash, outputHash, err := contract.HpcrTgz(folderPath)
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(folderPath))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(folderPath))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	var tgzBase64, inputHash, outputHash string
+	var err error
+	if !data.CompressionLevel.IsNull() && !data.CompressionLevel.IsUnknown() {
+		// Build the archive locally to honour the requested compression level
+		tgzBase64, err = common.GenerateTgzBase64(folderPath, int(data.CompressionLevel.ValueInt64()))
+		inputHash = common.GenerateSha256(folderPath)
+		outputHash = common.GenerateSha256(tgzBase64)
+	} else {
+		// Create TGZ archive using the contract-go library
+		tgzBase64, inputHash, outputHash, err = contract.HpcrTgz(folderPath)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create TGZ archive",
@@ -184,3 +250,13 @@ func (r *TgzResource) Update(ctx context.Context, req resource.UpdateRequest, re
 func (r *TgzResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op for this resource type
 }
+
+func (r *TgzResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "tgz", r.idMode, req, resp)
+}
+
+func (r *TgzResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: addedAttributesUpgrader(tgzResourceAttrTypesV0),
+	}
+}
@@ -18,37 +18,61 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
 	"github.com/ibm-hyper-protect/contract-go/v2/contract"
 	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TgzEncryptedResource{}
+var _ resource.ResourceWithConfigure = &TgzEncryptedResource{}
+var _ resource.ResourceWithImportState = &TgzEncryptedResource{}
+var _ resource.ResourceWithUpgradeState = &TgzEncryptedResource{}
 
 func NewTgzEncryptedResource() resource.Resource {
 	return &TgzEncryptedResource{}
 }
 
 // TgzEncryptedResource defines the resource implementation.
-type TgzEncryptedResource struct{}
+type TgzEncryptedResource struct {
+	idMode string
+}
 
 // TgzEncryptedResourceModel describes the resource data model.
 type TgzEncryptedResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Folder    types.String `tfsdk:"folder"`
-	Cert      types.String `tfsdk:"cert"`
-	Platform  types.String `tfsdk:"platform"`
-	Version   types.String `tfsdk:"version"`
-	Rendered  types.String `tfsdk:"rendered"`
-	Sha256In  types.String `tfsdk:"sha256_in"`
-	Sha256Out types.String `tfsdk:"sha256_out"`
+	ID               types.String `tfsdk:"id"`
+	Folder           types.String `tfsdk:"folder"`
+	CompressionLevel types.Int64  `tfsdk:"compression_level"`
+	Cert             types.String `tfsdk:"cert"`
+	Platform         types.String `tfsdk:"platform"`
+	Version          types.String `tfsdk:"version"`
+	Rendered         types.String `tfsdk:"rendered"`
+	Sha256In         types.String `tfsdk:"sha256_in"`
+	Sha256Out        types.String `tfsdk:"sha256_out"`
+}
+
+// tgzEncryptedResourceAttrTypesV0 are the attribute types of schema version 0,
+// before compression_level was added.
+var tgzEncryptedResourceAttrTypesV0 = map[string]attr.Type{
+	"id":         types.StringType,
+	"folder":     types.StringType,
+	"cert":       types.StringType,
+	"platform":   types.StringType,
+	"version":    types.StringType,
+	"rendered":   types.StringType,
+	"sha256_in":  types.StringType,
+	"sha256_out": types.StringType,
 }
 
 func (r *TgzEncryptedResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,6 +81,8 @@ func (r *TgzEncryptedResource) Metadata(ctx context.Context, req resource.Metada
 
 func (r *TgzEncryptedResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
+
 		MarkdownDescription: "Generates an encrypted token from the TGZed files in the folder.",
 		Description:         "Generates an encrypted token from the TGZed files in the folder.",
 
@@ -74,6 +100,14 @@ func (r *TgzEncryptedResource) Schema(ctx context.Context, req resource.SchemaRe
 				Description:         "Path to the folder to encrypt",
 				Required:            true,
 			},
+			"compression_level": schema.Int64Attribute{
+				MarkdownDescription: "Gzip compression level of the archive, from `1` (fastest) to `9` (smallest). Defaults to the standard gzip level if not specified.",
+				Description:         "Gzip compression level of the archive, from 1 (fastest) to 9 (smallest)",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 9),
+				},
+			},
 			"cert": schema.StringAttribute{
 				MarkdownDescription: "Certificate used to encrypt the JSON document, in PEM format. Defaults to the latest HPCR image certificate if not specified.",
 				Description:         "Certificate used to encrypt the JSON document, in PEM format",
@@ -83,11 +117,17 @@ func (r *TgzEncryptedResource) Schema(ctx context.Context, req resource.SchemaRe
 				MarkdownDescription: "Hyper Protect platform where this contract will be deployed. Defaults to hpvs",
 				Description:         "Hyper Protect platform where this contract will be deployed",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.Platform(),
+				},
 			},
 			"version": schema.StringAttribute{
 				MarkdownDescription: "Version of the Hyper Protect Platform",
 				Description:         "Version of the Hyper Protect Platform",
 				Optional:            true,
+				Validators: []validator.String{
+					validators.PlatformVersion(path.Root("platform")),
+				},
 			},
 			"rendered": schema.StringAttribute{
 				MarkdownDescription: "Rendered output of the resource",
@@ -108,6 +148,10 @@ func (r *TgzEncryptedResource) Schema(ctx context.Context, req resource.SchemaRe
 	}
 }
 
+func (r *TgzEncryptedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
 func (r *TgzEncryptedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data TgzEncryptedResourceModel
 
@@ -145,8 +189,20 @@ func (r *TgzEncryptedResource) Create(ctx context.Context, req resource.CreateRe
 		)
 	}
 
-	// Encrypt TGZ archive using the contract-go library
-	encrypted, inputHash, outputHash, err := contract.HpcrTgzEncrypted(folderPath, platform, version, cert)
+	var encrypted, inputHash, outputHash string
+	var err error
+	if !data.CompressionLevel.IsNull() && !data.CompressionLevel.IsUnknown() {
+		// Build the archive locally to honour the requested compression level
+		var tgzBase64 string
+		tgzBase64, err = common.GenerateTgzBase64(folderPath, int(data.CompressionLevel.ValueInt64()))
+		if err == nil {
+			encrypted, _, outputHash, err = contract.HpcrTextEncrypted(tgzBase64, platform, version, cert)
+			inputHash = common.GenerateSha256(folderPath)
+		}
+	} else {
+		// Encrypt TGZ archive using the contract-go library
+		encrypted, inputHash, outputHash, err = contract.HpcrTgzEncrypted(folderPath, platform, version, cert)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encrypt TGZ archive",
@@ -155,8 +211,8 @@ func (r *TgzEncryptedResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	// Generate UUID for the resource ID
-	id, err := common.GenerateID()
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash, platform, version, common.GenerateSha256(cert))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to generate ID",
@@ -229,8 +285,35 @@ func (r *TgzEncryptedResource) Update(ctx context.Context, req resource.UpdateRe
 		)
 	}
 
-	// Encrypt TGZ archive using the contract-go library
-	encrypted, inputHash, outputHash, err := contract.HpcrTgzEncrypted(folderPath, platform, version, cert)
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(folderPath))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(folderPath))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	var encrypted, inputHash, outputHash string
+	var err error
+	if !data.CompressionLevel.IsNull() && !data.CompressionLevel.IsUnknown() {
+		// Build the archive locally to honour the requested compression level
+		var tgzBase64 string
+		tgzBase64, err = common.GenerateTgzBase64(folderPath, int(data.CompressionLevel.ValueInt64()))
+		if err == nil {
+			encrypted, _, outputHash, err = contract.HpcrTextEncrypted(tgzBase64, platform, version, cert)
+			inputHash = common.GenerateSha256(folderPath)
+		}
+	} else {
+		// Encrypt TGZ archive using the contract-go library
+		encrypted, inputHash, outputHash, err = contract.HpcrTgzEncrypted(folderPath, platform, version, cert)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to encrypt TGZ archive",
@@ -251,3 +334,13 @@ func (r *TgzEncryptedResource) Update(ctx context.Context, req resource.UpdateRe
 func (r *TgzEncryptedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No-op for this resource type
 }
+
+func (r *TgzEncryptedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "tgz_encrypted", r.idMode, req, resp)
+}
+
+func (r *TgzEncryptedResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: addedAttributesUpgrader(tgzEncryptedResourceAttrTypesV0),
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 )
 
 func TestTgzEncryptedResource_Metadata(t *testing.T) {
@@ -49,7 +50,7 @@ func TestTgzEncryptedResource_Schema(t *testing.T) {
 		t.Fatal("Schema attributes should not be nil")
 	}
 
-	requiredAttrs := []string{"id", "folder", "cert", "platform", "rendered", "sha256_in", "sha256_out"}
+	requiredAttrs := []string{"id", "folder", "cert", "platform", "compression_level", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("Expected schema to have attribute '%s'", attr)
@@ -73,6 +74,12 @@ func TestTgzEncryptedResource_Schema(t *testing.T) {
 		t.Error("Expected 'platform' attribute to be optional")
 	}
 
+	// Verify compression_level is optional
+	compressionLevelAttr := resp.Schema.Attributes["compression_level"]
+	if compressionLevelAttr.IsOptional() == false {
+		t.Error("Expected 'compression_level' attribute to be optional")
+	}
+
 	// Verify computed attributes
 	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range computedAttrs {
@@ -132,3 +139,20 @@ func TestTgzEncryptedResource_Delete(t *testing.T) {
 		t.Error("Delete should not produce errors")
 	}
 }
+
+func TestTgzEncryptedResource_PlatformValidators(t *testing.T) {
+	r := NewTgzEncryptedResource()
+
+	req := resource.SchemaRequest{}
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.TODO(), req, resp)
+
+	// Verify platform and version are validated at plan time
+	for _, attr := range []string{"platform", "version"} {
+		stringAttr, ok := resp.Schema.Attributes[attr].(schema.StringAttribute)
+		if !ok || len(stringAttr.Validators) == 0 {
+			t.Errorf("Expected '%s' attribute to have validators", attr)
+		}
+	}
+}
@@ -49,7 +49,7 @@ func TestTgzResource_Schema(t *testing.T) {
 		t.Fatal("Schema attributes should not be nil")
 	}
 
-	requiredAttrs := []string{"id", "folder", "rendered", "sha256_in", "sha256_out"}
+	requiredAttrs := []string{"id", "folder", "compression_level", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range requiredAttrs {
 		if _, ok := resp.Schema.Attributes[attr]; !ok {
 			t.Errorf("Expected schema to have attribute '%s'", attr)
@@ -62,6 +62,12 @@ func TestTgzResource_Schema(t *testing.T) {
 		t.Error("Expected 'folder' attribute to be required")
 	}
 
+	// Verify compression_level is optional
+	compressionLevelAttr := resp.Schema.Attributes["compression_level"]
+	if compressionLevelAttr.IsOptional() == false {
+		t.Error("Expected 'compression_level' attribute to be optional")
+	}
+
 	// Verify computed attributes
 	computedAttrs := []string{"id", "rendered", "sha256_in", "sha256_out"}
 	for _, attr := range computedAttrs {
@@ -0,0 +1,214 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/contract-go/v2/contract"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ resource.Resource = &YAMLResource{}
+var _ resource.ResourceWithConfigure = &YAMLResource{}
+var _ resource.ResourceWithImportState = &YAMLResource{}
+
+func NewYAMLResource() resource.Resource {
+	return &YAMLResource{}
+}
+
+type YAMLResource struct {
+	idMode string
+}
+
+type YAMLResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	YAML          types.String `tfsdk:"yaml"`
+	MultiDocument types.Bool   `tfsdk:"multi_document"`
+	Rendered      types.String `tfsdk:"rendered"`
+	Sha256In      types.String `tfsdk:"sha256_in"`
+	Sha256Out     types.String `tfsdk:"sha256_out"`
+}
+
+func (r *YAMLResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_yaml"
+}
+
+func (r *YAMLResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a base64 encoded token from the normalized serialization of a YAML document.",
+		Description:         "Generates a base64 encoded token from the normalized serialization of a YAML document.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"yaml": schema.StringAttribute{
+				MarkdownDescription: "YAML document to archive",
+				Description:         "YAML document to archive",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"multi_document": schema.BoolAttribute{
+				MarkdownDescription: "Allow more than one YAML document, separated by `---`. Defaults to `false`.",
+				Description:         "Allow more than one YAML document",
+				Optional:            true,
+			},
+			"rendered": schema.StringAttribute{
+				MarkdownDescription: "Rendered output of the resource",
+				Description:         "Rendered output of the resource",
+				Computed:            true,
+			},
+			"sha256_in": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the input",
+				Description:         "SHA256 of the input",
+				Computed:            true,
+			},
+			"sha256_out": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the output",
+				Description:         "SHA256 of the output",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *YAMLResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
+func (r *YAMLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data YAMLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the input YAML
+	normalized, err := common.NormalizeYAML(data.YAML.ValueString(), data.MultiDocument.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to normalize YAML",
+			fmt.Sprintf("Error normalizing YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Encode YAML using the contract-go library
+	encoded, inputHash, outputHash, err := contract.HpcrText(normalized)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to encode YAML",
+			fmt.Sprintf("Error encoding YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields
+	data.ID = types.StringValue(id)
+	data.Rendered = types.StringValue(encoded)
+	data.Sha256In = types.StringValue(inputHash)
+	data.Sha256Out = types.StringValue(outputHash)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *YAMLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data YAMLResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *YAMLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data YAMLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the input YAML
+	normalized, err := common.NormalizeYAML(data.YAML.ValueString(), data.MultiDocument.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to normalize YAML",
+			fmt.Sprintf("Error normalizing YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(normalized))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(normalized))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Encode YAML using the contract-go library
+	encoded, inputHash, outputHash, err := contract.HpcrText(normalized)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to encode YAML",
+			fmt.Sprintf("Error encoding YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields (keep the existing ID)
+	data.Rendered = types.StringValue(encoded)
+	data.Sha256In = types.StringValue(inputHash)
+	data.Sha256Out = types.StringValue(outputHash)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *YAMLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No-op
+}
+
+func (r *YAMLResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "yaml", r.idMode, req, resp)
+}
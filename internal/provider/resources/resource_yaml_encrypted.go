@@ -0,0 +1,300 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/contract-go/v2/certificate"
+	"github.com/ibm-hyper-protect/contract-go/v2/contract"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/internal/provider/validators"
+)
+
+var _ resource.Resource = &YAMLEncryptedResource{}
+var _ resource.ResourceWithConfigure = &YAMLEncryptedResource{}
+var _ resource.ResourceWithImportState = &YAMLEncryptedResource{}
+
+func NewYAMLEncryptedResource() resource.Resource {
+	return &YAMLEncryptedResource{}
+}
+
+type YAMLEncryptedResource struct {
+	idMode string
+}
+
+type YAMLEncryptedResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	YAML          types.String `tfsdk:"yaml"`
+	MultiDocument types.Bool   `tfsdk:"multi_document"`
+	Cert          types.String `tfsdk:"cert"`
+	Platform      types.String `tfsdk:"platform"`
+	Version       types.String `tfsdk:"version"`
+	Rendered      types.String `tfsdk:"rendered"`
+	Sha256In      types.String `tfsdk:"sha256_in"`
+	Sha256Out     types.String `tfsdk:"sha256_out"`
+}
+
+func (r *YAMLEncryptedResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_yaml_encrypted"
+}
+
+func (r *YAMLEncryptedResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates an encrypted token from the normalized serialization of a YAML document.",
+		Description:         "Generates an encrypted token from the normalized serialization of a YAML document.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"yaml": schema.StringAttribute{
+				MarkdownDescription: "YAML document to encrypt",
+				Description:         "YAML document to encrypt",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"multi_document": schema.BoolAttribute{
+				MarkdownDescription: "Allow more than one YAML document, separated by `---`. Defaults to `false`.",
+				Description:         "Allow more than one YAML document",
+				Optional:            true,
+			},
+			"cert": schema.StringAttribute{
+				MarkdownDescription: "Certificate used to encrypt the YAML document, in PEM format. Defaults to the latest HPCR image certificate if not specified.",
+				Description:         "Certificate used to encrypt the YAML document, in PEM format",
+				Optional:            true,
+			},
+			"platform": schema.StringAttribute{
+				MarkdownDescription: "Hyper Protect platform where this contract will be deployed. Defaults to hpvs",
+				Description:         "Hyper Protect platform where this contract will be deployed",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Platform(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the Hyper Protect Platform",
+				Description:         "Version of the Hyper Protect Platform",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.PlatformVersion(path.Root("platform")),
+				},
+			},
+			"rendered": schema.StringAttribute{
+				MarkdownDescription: "Rendered output of the resource",
+				Description:         "Rendered output of the resource",
+				Computed:            true,
+			},
+			"sha256_in": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the input",
+				Description:         "SHA256 of the input",
+				Computed:            true,
+			},
+			"sha256_out": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the output",
+				Description:         "SHA256 of the output",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *YAMLEncryptedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.idMode = providerIDMode(req, resp)
+}
+
+func (r *YAMLEncryptedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data YAMLEncryptedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the input YAML
+	normalized, err := common.NormalizeYAML(data.YAML.ValueString(), data.MultiDocument.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to normalize YAML",
+			fmt.Sprintf("Error normalizing YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Get the certificate (empty string will use default)
+	cert := ""
+	if !data.Cert.IsNull() && !data.Cert.IsUnknown() {
+		cert = data.Cert.ValueString()
+
+		// check expiry of the encryption certificate
+		expiryInfo, err := certificate.HpcrValidateEncryptionCertificate(cert)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Fail to encrypt YAML",
+				fmt.Sprintf("Encryption certificate has expired: %s", err.Error()),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Encryption certificate validity",
+			expiryInfo,
+		)
+	}
+
+	// Get the platform (empty string will use default "hpvs")
+	platform := ""
+	if !data.Platform.IsNull() && !data.Platform.IsUnknown() {
+		platform = data.Platform.ValueString()
+	}
+
+	version := data.Version.ValueString()
+
+	// Encrypt YAML using the contract-go library
+	encrypted, inputHash, outputHash, err := contract.HpcrTextEncrypted(normalized, platform, version, cert)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to encrypt YAML",
+			fmt.Sprintf("Error encrypting YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Generate the resource ID, derived from the inputs if the provider uses stable IDs
+	id, err := common.ResourceID(r.idMode, inputHash, platform, version, common.GenerateSha256(cert))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to generate ID",
+			fmt.Sprintf("Error generating ID for resource: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields
+	data.ID = types.StringValue(id)
+	data.Rendered = types.StringValue(encrypted)
+	data.Sha256In = types.StringValue(inputHash)
+	data.Sha256Out = types.StringValue(outputHash)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *YAMLEncryptedResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data YAMLEncryptedResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *YAMLEncryptedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data YAMLEncryptedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate and normalize the input YAML
+	normalized, err := common.NormalizeYAML(data.YAML.ValueString(), data.MultiDocument.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to normalize YAML",
+			fmt.Sprintf("Error normalizing YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Get the certificate (empty string will use default)
+	cert := ""
+	if !data.Cert.IsNull() && !data.Cert.IsUnknown() {
+		cert = data.Cert.ValueString()
+
+		// check expiry of the encryption certificate
+		expiryInfo, err := certificate.HpcrValidateEncryptionCertificate(cert)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Fail to encrypt YAML",
+				fmt.Sprintf("Encryption certificate has expired: %s", err.Error()),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Encryption certificate validity",
+			expiryInfo,
+		)
+	}
+
+	// Get the platform (empty string will use default "hpvs")
+	platform := ""
+	if !data.Platform.IsNull() && !data.Platform.IsUnknown() {
+		platform = data.Platform.ValueString()
+	}
+
+	version := data.Version.ValueString()
+
+	// Keep the imported output if the input did not change, so that it is not generated again
+	importedOutput, keep, diags := importedRendered(ctx, req.Private, req.State, common.GenerateSha256(normalized))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if keep {
+		data.Rendered = types.StringValue(importedOutput)
+		data.Sha256In = types.StringValue(common.GenerateSha256(normalized))
+		data.Sha256Out = types.StringValue(common.GenerateSha256(importedOutput))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Encrypt YAML using the contract-go library
+	encrypted, inputHash, outputHash, err := contract.HpcrTextEncrypted(normalized, platform, version, cert)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to encrypt YAML",
+			fmt.Sprintf("Error encrypting YAML: %s", err.Error()),
+		)
+		return
+	}
+
+	// Set the computed fields (keep the existing ID)
+	data.Rendered = types.StringValue(encrypted)
+	data.Sha256In = types.StringValue(inputHash)
+	data.Sha256Out = types.StringValue(outputHash)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *YAMLEncryptedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// No-op
+}
+
+func (r *YAMLEncryptedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRendered(ctx, "yaml_encrypted", r.idMode, req, resp)
+}
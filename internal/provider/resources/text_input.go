@@ -0,0 +1,69 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validateTextInput ensures that exactly one of the text and content_base64 attributes is configured.
+func validateTextInput(text types.String, contentBase64 types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Unknown values are validated again once they are known
+	if text.IsUnknown() || contentBase64.IsUnknown() {
+		return diags
+	}
+
+	if text.IsNull() == contentBase64.IsNull() {
+		diags.AddAttributeError(
+			path.Root("content_base64"),
+			"Invalid text input",
+			"Exactly one of 'text' or 'content_base64' must be provided.",
+		)
+		return diags
+	}
+
+	if !contentBase64.IsNull() {
+		if _, err := base64.StdEncoding.DecodeString(contentBase64.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content_base64"),
+				"Invalid base64 content",
+				fmt.Sprintf("'content_base64' must be base64-encoded (use filebase64() in Terraform): %s", err.Error()),
+			)
+		}
+	}
+
+	return diags
+}
+
+// textInput returns the raw bytes of either the text or the decoded content_base64 attribute.
+func textInput(text types.String, contentBase64 types.String) ([]byte, error) {
+	if contentBase64.IsNull() {
+		return []byte(text.ValueString()), nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(contentBase64.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("'content_base64' must be base64-encoded: %v", err)
+	}
+
+	return content, nil
+}
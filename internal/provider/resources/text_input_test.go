@@ -0,0 +1,69 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateTextInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          types.String
+		contentBase64 types.String
+		wantErr       bool
+	}{
+		{"text only", types.StringValue("hello"), types.StringNull(), false},
+		{"content only", types.StringNull(), types.StringValue("AAEC/w=="), false},
+		{"both set", types.StringValue("hello"), types.StringValue("AAEC/w=="), true},
+		{"none set", types.StringNull(), types.StringNull(), true},
+		{"invalid base64", types.StringNull(), types.StringValue("not base64!"), true},
+		{"unknown content", types.StringNull(), types.StringUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateTextInput(tt.text, tt.contentBase64)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validateTextInput() errors = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTextInput_Binary(t *testing.T) {
+	input, err := textInput(types.StringNull(), types.StringValue("AAEC/w=="))
+	if err != nil {
+		t.Fatalf("textInput() failed: %v", err)
+	}
+
+	expected := []byte{0x00, 0x01, 0x02, 0xff}
+	if !bytes.Equal(input, expected) {
+		t.Errorf("Expected %v, got %v", expected, input)
+	}
+}
+
+func TestTextInput_Text(t *testing.T) {
+	input, err := textInput(types.StringValue("hello"), types.StringNull())
+	if err != nil {
+		t.Fatalf("textInput() failed: %v", err)
+	}
+	if string(input) != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", string(input))
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// priorSchema builds the schema of an earlier version of a resource from the
+// types of its attributes. The schema is only used to decode state of that
+// version, so all attributes are optional and have no description.
+func priorSchema(attributeTypes map[string]attr.Type) *schema.Schema {
+	attributes := make(map[string]schema.Attribute, len(attributeTypes))
+	for name, attributeType := range attributeTypes {
+		switch attributeType := attributeType.(type) {
+		case types.MapType:
+			attributes[name] = schema.MapAttribute{ElementType: attributeType.ElemType, Optional: true}
+		case types.ListType:
+			attributes[name] = schema.ListAttribute{ElementType: attributeType.ElemType, Optional: true}
+		default:
+			switch attributeType {
+			case types.Int64Type:
+				attributes[name] = schema.Int64Attribute{Optional: true}
+			case types.Float64Type:
+				attributes[name] = schema.Float64Attribute{Optional: true}
+			case types.BoolType:
+				attributes[name] = schema.BoolAttribute{Optional: true}
+			case types.DynamicType:
+				attributes[name] = schema.DynamicAttribute{Optional: true}
+			default:
+				attributes[name] = schema.StringAttribute{Optional: true}
+			}
+		}
+	}
+	return &schema.Schema{Attributes: attributes}
+}
+
+// addedAttributesUpgrader returns a state upgrader from an earlier schema
+// version that only lacks attributes added since. The attributes of the earlier
+// version are kept and the added attributes are null.
+func addedAttributesUpgrader(priorAttributeTypes map[string]attr.Type) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		PriorSchema: priorSchema(priorAttributeTypes),
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			var prior map[string]tftypes.Value
+			if err := req.State.Raw.As(&prior); err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to upgrade state",
+					fmt.Sprintf("Error reading prior state: %s", err.Error()),
+				)
+				return
+			}
+
+			objectType := resp.State.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				if value, ok := prior[name]; ok {
+					values[name] = value
+				} else {
+					values[name] = tftypes.NewValue(attributeType, nil)
+				}
+			}
+			resp.State.Raw = tftypes.NewValue(objectType, values)
+		},
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPriorSchema(t *testing.T) {
+	attributeTypes := map[string]attr.Type{
+		"id":      types.StringType,
+		"count":   types.Int64Type,
+		"ratio":   types.Float64Type,
+		"enabled": types.BoolType,
+		"value":   types.DynamicType,
+		"labels":  types.MapType{ElemType: types.StringType},
+		"names":   types.ListType{ElemType: types.StringType},
+	}
+
+	prior := priorSchema(attributeTypes)
+
+	if len(prior.Attributes) != len(attributeTypes) {
+		t.Fatalf("Expected %d attributes, got %d", len(attributeTypes), len(prior.Attributes))
+	}
+	for name, attributeType := range attributeTypes {
+		if got := prior.Attributes[name].GetType(); !got.Equal(attributeType) {
+			t.Errorf("Expected attribute '%s' to have type %s, got %s", name, attributeType, got)
+		}
+	}
+}
+
+func TestUpgradeState(t *testing.T) {
+	// Resources whose schema gained attributes since the last release
+	tests := []struct {
+		name     string
+		resource resource.ResourceWithUpgradeState
+		added    []string
+	}{
+		{"text", &TextResource{}, []string{"content_base64", "compression", "compression_ratio"}},
+		{"text_encrypted", &TextEncryptedResource{}, []string{"content_base64", "compression", "compression_ratio"}},
+		{"json", &JSONResource{}, []string{"value", "compression", "compression_ratio"}},
+		{"json_encrypted", &JSONEncryptedResource{}, []string{"value", "compression", "compression_ratio"}},
+		{"tgz", &TgzResource{}, []string{"compression_level"}},
+		{"tgz_encrypted", &TgzEncryptedResource{}, []string{"compression_level"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			tt.resource.Schema(context.TODO(), resource.SchemaRequest{}, resp)
+
+			if resp.Schema.Version != 1 {
+				t.Errorf("Expected schema version 1, got %d", resp.Schema.Version)
+			}
+
+			upgrader, ok := tt.resource.UpgradeState(context.TODO())[0]
+			if !ok {
+				t.Fatal("Expected a state upgrader for schema version 0")
+			}
+			if len(upgrader.PriorSchema.Attributes)+len(tt.added) != len(resp.Schema.Attributes) {
+				t.Errorf("Expected version 0 to lack exactly %v, got %d attributes", tt.added, len(upgrader.PriorSchema.Attributes))
+			}
+			for _, name := range tt.added {
+				if _, ok := upgrader.PriorSchema.Attributes[name]; ok {
+					t.Errorf("Expected attribute '%s' to be missing from version 0", name)
+				}
+			}
+
+			// Upgrade a state of version 0 that has a value for every attribute
+			priorType := upgrader.PriorSchema.Type().TerraformType(context.TODO()).(tftypes.Object)
+			priorValues := make(map[string]tftypes.Value, len(priorType.AttributeTypes))
+			for name := range priorType.AttributeTypes {
+				priorValues[name] = tftypes.NewValue(tftypes.String, name+"-v0")
+			}
+			req := resource.UpgradeStateRequest{
+				State: &tfsdk.State{Schema: upgrader.PriorSchema, Raw: tftypes.NewValue(priorType, priorValues)},
+			}
+			upgradeResp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: resp.Schema}}
+			upgrader.StateUpgrader(context.TODO(), req, upgradeResp)
+			if upgradeResp.Diagnostics.HasError() {
+				t.Fatalf("Upgrade failed: %v", upgradeResp.Diagnostics)
+			}
+
+			for name := range resp.Schema.Attributes {
+				var value attr.Value
+				if diags := upgradeResp.State.GetAttribute(context.TODO(), path.Root(name), &value); diags.HasError() {
+					t.Fatalf("Failed to read attribute '%s': %v", name, diags)
+				}
+				if _, ok := priorValues[name]; ok {
+					if value.(types.String).ValueString() != name+"-v0" {
+						t.Errorf("Expected attribute '%s' to be kept, got %s", name, value)
+					}
+				} else if !value.IsNull() {
+					t.Errorf("Expected added attribute '%s' to be null, got %s", name, value)
+				}
+			}
+		})
+	}
+}
+
+func TestUpgradeState_Unversioned(t *testing.T) {
+	// Resources whose schema did not change since the last release, or that
+	// have not been released yet
+	tests := []struct {
+		name     string
+		resource resource.Resource
+	}{
+		{"contract_encrypted", &ContractEncryptedResource{}},
+		{"contract_encrypted_contract_expiry", &ContractEncryptedContractExpiryResource{}},
+		{"yaml", &YAMLResource{}},
+		{"yaml_encrypted", &YAMLEncryptedResource{}},
+		{"attestation_keypair", &AttestationKeypairResource{}},
+		{"cloudinit_iso", &CloudInitISOResource{}},
+		{"peerpod_initdata", &PeerPodInitDataResource{}},
+		{"contract_set", &ContractSetResource{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			tt.resource.Schema(context.TODO(), resource.SchemaRequest{}, resp)
+
+			if resp.Schema.Version != 0 {
+				t.Errorf("Expected schema version 0, got %d", resp.Schema.Version)
+			}
+			if _, ok := tt.resource.(resource.ResourceWithUpgradeState); ok {
+				t.Error("Expected no state upgraders")
+			}
+		})
+	}
+}
+
+func TestContractEncryptedContractExpiryResource_MoveState(t *testing.T) {
+	r := &ContractEncryptedContractExpiryResource{}
+
+	movers := r.MoveState(context.TODO())
+	if len(movers) != 1 {
+		t.Fatalf("Expected 1 state mover, got %d", len(movers))
+	}
+	if movers[0].SourceSchema == nil {
+		t.Error("Expected the state mover to have a source schema")
+	}
+	if _, ok := movers[0].SourceSchema.Attributes["contract"]; !ok {
+		t.Error("Expected the source schema to have attribute 'contract'")
+	}
+
+	// Other source resources are skipped without error
+	req := resource.MoveStateRequest{
+		SourceTypeName:        "hpcr_text_encrypted",
+		SourceProviderAddress: "registry.terraform.io/ibm-hyper-protect/hpcr",
+	}
+	resp := &resource.MoveStateResponse{}
+	movers[0].StateMover(context.TODO(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("Expected no error for another source resource, got %v", resp.Diagnostics)
+	}
+	if resp.TargetState.Raw.IsKnown() && !resp.TargetState.Raw.IsNull() {
+		t.Error("Expected the target state to be left unset for another source resource")
+	}
+
+	// Unknown schema versions are rejected
+	req.SourceTypeName = "hpcr_contract_encrypted"
+	req.SourceSchemaVersion = 1
+	resp = &resource.MoveStateResponse{}
+	movers[0].StateMover(context.TODO(), req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for an unsupported source schema version")
+	}
+}
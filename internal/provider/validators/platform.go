@@ -0,0 +1,104 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ibm-hyper-protect/terraform-provider-hpcr/common"
+)
+
+var _ validator.String = platformValidator{}
+var _ validator.String = platformVersionValidator{}
+
+// Platform returns a validator which ensures that the value is one of the
+// supported Hyper Protect platforms.
+func Platform() validator.String {
+	return platformValidator{}
+}
+
+// PlatformVersion returns a validator which ensures that the value is a
+// version number for the platform configured in the attribute at
+// platformPath. Only the format is checked, not whether the release exists.
+func PlatformVersion(platformPath path.Path) validator.String {
+	return platformVersionValidator{platformPath: platformPath}
+}
+
+type platformValidator struct{}
+
+func (v platformValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(common.PlatformNames(), ", "))
+}
+
+func (v platformValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: `%s`", strings.Join(common.PlatformNames(), "`, `"))
+}
+
+func (v platformValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := common.LookupPlatform(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid platform",
+			err.Error(),
+		)
+	}
+}
+
+type platformVersionValidator struct {
+	platformPath path.Path
+}
+
+func (v platformVersionValidator) Description(ctx context.Context) string {
+	return "value must be a version number such as 1.0.23 or 25.11.0"
+}
+
+func (v platformVersionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v platformVersionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var platform types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.platformPath, &platform)...)
+	if resp.Diagnostics.HasError() || platform.IsUnknown() {
+		return
+	}
+
+	// An invalid platform is reported by the platform validator
+	if _, err := common.LookupPlatform(platform.ValueString()); err != nil {
+		return
+	}
+
+	if err := common.ValidatePlatformVersion(platform.ValueString(), req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid platform version",
+			err.Error(),
+		)
+	}
+}
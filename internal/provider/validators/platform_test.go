@@ -0,0 +1,81 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPlatform(t *testing.T) {
+	tests := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"null":         {value: types.StringNull()},
+		"unknown":      {value: types.StringUnknown()},
+		"hpvs":         {value: types.StringValue("hpvs")},
+		"hpcc-peerpod": {value: types.StringValue("hpcc-peerpod")},
+		"trailing":     {value: types.StringValue("hpvs "), expectErr: true},
+		"unsupported":  {value: types.StringValue("unknown"), expectErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("platform"),
+				ConfigValue: test.value,
+			}
+			resp := &validator.StringResponse{}
+
+			Platform().ValidateString(context.TODO(), req, resp)
+
+			if resp.Diagnostics.HasError() != test.expectErr {
+				t.Errorf("Expected error %t, got diagnostics %v", test.expectErr, resp.Diagnostics)
+			}
+			if test.expectErr && !resp.Diagnostics.Errors()[0].(interface{ Path() path.Path }).Path().Equal(path.Root("platform")) {
+				t.Error("Expected the error to point at the platform attribute")
+			}
+		})
+	}
+}
+
+func TestPlatformVersion_SkipsUnsetValues(t *testing.T) {
+	for _, value := range []types.String{types.StringNull(), types.StringUnknown()} {
+		req := validator.StringRequest{
+			Path:        path.Root("version"),
+			ConfigValue: value,
+		}
+		resp := &validator.StringResponse{}
+
+		PlatformVersion(path.Root("platform")).ValidateString(context.TODO(), req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("Expected no error for %v, got %v", value, resp.Diagnostics)
+		}
+	}
+}
+
+func TestValidatorDescriptions(t *testing.T) {
+	for _, v := range []validator.String{Platform(), PlatformVersion(path.Root("platform"))} {
+		if v.Description(context.TODO()) == "" || v.MarkdownDescription(context.TODO()) == "" {
+			t.Errorf("Expected validator %T to have descriptions", v)
+		}
+	}
+}
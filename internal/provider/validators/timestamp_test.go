@@ -0,0 +1,54 @@
+// Copyright 2026 IBM Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRFC3339(t *testing.T) {
+	tests := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"null":      {value: types.StringNull()},
+		"unknown":   {value: types.StringUnknown()},
+		"utc":       {value: types.StringValue("2025-01-01T00:00:00Z")},
+		"offset":    {value: types.StringValue("2025-01-01T02:00:00+02:00")},
+		"date only": {value: types.StringValue("2025-01-01"), expectErr: true},
+		"text":      {value: types.StringValue("yesterday"), expectErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("created_after"),
+				ConfigValue: test.value,
+			}
+			resp := &validator.StringResponse{}
+
+			RFC3339().ValidateString(context.TODO(), req, resp)
+
+			if resp.Diagnostics.HasError() != test.expectErr {
+				t.Errorf("Expected error %t, got diagnostics %v", test.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}